@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	CDCStrategyNone               = "None"
+	CDCStrategyReplacingMergeTree = "ReplacingMergeTree"
+)
+
+const (
+	CDCVersionLSN  = "lsn"
+	CDCVersionTsMs = "ts_ms"
+)
+
+// Columns appended by the ReplacingMergeTree strategy, the version of the row and its soft-delete flag.
+const (
+	CDCVersionColumn   = "_version"
+	CDCIsDeletedColumn = "_is_deleted"
+)
+
+// Debezium fields added to the records by the ExtractNewRecordState transform
+// with add.fields=op,lsn,ts_ms, read by the kafka engine table.
+const (
+	DebeziumOpField   = "__op"
+	DebeziumLSNField  = "__lsn"
+	DebeziumTsMsField = "__ts_ms"
+)
+
+// DebeziumOpDelete is the operation of the delete records, rewritten with delete.handling.mode=rewrite.
+const DebeziumOpDelete = "d"
+
+// cdcConversion returns the conversion of the columns appended by the ReplacingMergeTree
+// strategy, along with the kafka engine columns of the Debezium fields they are computed from.
+func cdcConversion(version string) (error, psqlColumnConversion, []ClickhouseColumn) {
+	var conversion psqlColumnConversion
+	versionField := DebeziumLSNField
+	if version == CDCVersionTsMs {
+		versionField = DebeziumTsMsField
+	}
+	kafkaEngineColumns := []ClickhouseColumn{
+		{Name: types.StringValue(DebeziumOpField), Type: types.StringValue("Nullable(String)")},
+		{Name: types.StringValue(versionField), Type: types.StringValue("Nullable(Int64)")},
+	}
+	for _, column := range []ClickhouseColumn{
+		{Name: types.StringValue(CDCVersionColumn), Type: types.StringValue("UInt64")},
+		{Name: types.StringValue(CDCIsDeletedColumn), Type: types.StringValue("UInt8")},
+	} {
+		err, athenaType := clickhouseToAthena(column.Type.ValueString())
+		if err != nil {
+			return err, conversion, nil
+		}
+		conversion.ClickhouseColumns = append(conversion.ClickhouseColumns, column)
+		conversion.AthenaColumns = append(conversion.AthenaColumns, AthenaColumn{Name: column.Name, Type: types.StringValue(athenaType)})
+	}
+	conversion.KafkaEngineColumnsMapping = []attr.Value{
+		types.StringValue("toUInt64(ifNull(" + quoteClickhouseIdentifier(versionField) + ", 0)) as " + quoteClickhouseIdentifier(CDCVersionColumn)),
+		types.StringValue("if(" + quoteClickhouseIdentifier(DebeziumOpField) + " = " + quoteClickhouseString(DebeziumOpDelete) + ", 1, 0) as " + quoteClickhouseIdentifier(CDCIsDeletedColumn)),
+	}
+	return nil, conversion, kafkaEngineColumns
+}
+
+// cdcEngine is the default engine of the CDC strategy.
+func cdcEngine(strategy string) string {
+	if strategy == CDCStrategyReplacingMergeTree {
+		return CDCStrategyReplacingMergeTree + "(" + quoteClickhouseIdentifier(CDCVersionColumn) + ", " + quoteClickhouseIdentifier(CDCIsDeletedColumn) + ")"
+	}
+	return ClickhouseEngineMergeTree
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestCDCConversion(t *testing.T) {
+	testCases := map[string]string{
+		CDCVersionLSN:  "toUInt64(ifNull(`__lsn`, 0)) as `_version`",
+		CDCVersionTsMs: "toUInt64(ifNull(`__ts_ms`, 0)) as `_version`",
+	}
+	for version, expected := range testCases {
+		err, conversion, kafkaEngineColumns := cdcConversion(version)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %s", version, err)
+		}
+		if len(conversion.ClickhouseColumns) != 2 || len(conversion.AthenaColumns) != 2 || len(kafkaEngineColumns) != 2 {
+			t.Fatalf("%s: expected 2 columns, got %+v", version, conversion)
+		}
+		if got := conversion.KafkaEngineColumnsMapping[0].String(); got != `"`+expected+`"` {
+			t.Errorf("%s: expected %s, got %s", version, expected, got)
+		}
+	}
+}
+
+func TestCDCEngine(t *testing.T) {
+	if engine := cdcEngine(CDCStrategyReplacingMergeTree); engine != "ReplacingMergeTree(`_version`, `_is_deleted`)" {
+		t.Errorf("unexpected engine %s", engine)
+	}
+	if engine := cdcEngine(""); engine != ClickhouseEngineMergeTree {
+		t.Errorf("unexpected engine %s", engine)
+	}
+}
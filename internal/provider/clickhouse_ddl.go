@@ -0,0 +1,221 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ClickhouseTable describes the options of the generated Clickhouse table.
+type ClickhouseTable struct {
+	Database    types.String            `tfsdk:"database"`
+	Engine      types.String            `tfsdk:"engine"`
+	OrderBy     []types.String          `tfsdk:"order_by"`
+	PartitionBy types.String            `tfsdk:"partition_by"`
+	Settings    map[string]types.String `tfsdk:"settings"`
+	Comment     types.String            `tfsdk:"comment"`
+}
+
+const ClickhouseEngineMergeTree = "MergeTree"
+
+// clickhouseTableOptions are the table options with their defaults applied.
+type clickhouseTableOptions struct {
+	Database    string
+	Engine      string
+	OrderBy     []string
+	PartitionBy string
+	Settings    map[string]string
+	Comment     string
+}
+
+// options returns the table options, defaultEngine being used when the engine is unset.
+func (t *ClickhouseTable) options(defaultEngine string) clickhouseTableOptions {
+	options := clickhouseTableOptions{Engine: defaultEngine}
+	if t == nil {
+		return options
+	}
+	options.Database = t.Database.ValueString()
+	if !t.Engine.IsNull() {
+		options.Engine = t.Engine.ValueString()
+	}
+	for _, expression := range t.OrderBy {
+		options.OrderBy = append(options.OrderBy, expression.ValueString())
+	}
+	options.PartitionBy = t.PartitionBy.ValueString()
+	if t.Settings != nil {
+		options.Settings = map[string]string{}
+		for name, value := range t.Settings {
+			options.Settings[name] = value.ValueString()
+		}
+	}
+	options.Comment = t.Comment.ValueString()
+	return options
+}
+
+type clickhouseColumnDefinition struct {
+	Name    string
+	Type    string
+	Comment string
+}
+
+// clickhouseCreateTable is a Clickhouse table, PrimaryKey being its primary key columns.
+type clickhouseCreateTable struct {
+	clickhouseTableOptions
+	Cluster     string
+	Name        string
+	Columns     []clickhouseColumnDefinition
+	Constraints []string
+	PrimaryKey  []string
+}
+
+type SortingKeyError struct {
+	PrimaryKey []string
+	OrderBy    []string
+}
+
+func (e *SortingKeyError) Error() string {
+	return fmt.Sprintf("Primary key %s must be a prefix of the sorting key %s", clickhouseTuple(e.PrimaryKey), clickhouseTuple(e.OrderBy))
+}
+
+// render returns the CREATE TABLE statement of the table. The primary key columns are the
+// sorting key unless order_by is set, the PRIMARY KEY clause being only rendered when the
+// primary key is a strict prefix of the sorting key. Engines outside the MergeTree family
+// have no keys.
+func (t clickhouseCreateTable) render() (error, string) {
+	var sb strings.Builder
+	sb.WriteString(renderClickhouseTableHead(t.Cluster, t.Database, t.Name, t.Columns, t.Constraints, t.Engine))
+	if t.isMergeTree() {
+		err, primaryKey, orderBy := t.sortingKey()
+		if err != nil {
+			return err, ""
+		}
+		if t.PartitionBy != "" {
+			sb.WriteString("PARTITION BY " + t.PartitionBy + "\n")
+		}
+		if len(primaryKey) > 0 {
+			sb.WriteString("PRIMARY KEY " + clickhouseTuple(primaryKey) + "\n")
+		}
+		sb.WriteString("ORDER BY " + clickhouseTuple(orderBy) + "\n")
+	}
+	if len(t.Settings) > 0 {
+		var names []string
+		for name := range t.Settings {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var settings []clickhouseSetting
+		for _, name := range names {
+			settings = append(settings, clickhouseSetting{Name: name, Value: clickhouseSettingValue(t.Settings[name])})
+		}
+		sb.WriteString(renderClickhouseSettings(settings))
+	}
+	if t.Comment != "" {
+		sb.WriteString("COMMENT " + quoteClickhouseString(t.Comment) + "\n")
+	}
+	return nil, sb.String()
+}
+
+// sortingKey returns the PRIMARY KEY clause columns, empty when they are the sorting key, and
+// the sorting key, the plain identifiers of order_by being quoted to compare them with the
+// primary key.
+func (t clickhouseCreateTable) sortingKey() (error, []string, []string) {
+	var primaryKey []string
+	for _, column := range t.PrimaryKey {
+		primaryKey = append(primaryKey, quoteClickhouseIdentifier(column))
+	}
+	if t.OrderBy == nil {
+		return nil, nil, primaryKey
+	}
+	var orderBy []string
+	for _, expression := range t.OrderBy {
+		expression = strings.TrimSpace(expression)
+		if regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`).MatchString(expression) {
+			expression = quoteClickhouseIdentifier(expression)
+		}
+		orderBy = append(orderBy, expression)
+	}
+	if len(primaryKey) == 0 || strings.Join(primaryKey, ", ") == strings.Join(orderBy, ", ") {
+		return nil, nil, orderBy
+	}
+	if len(primaryKey) > len(orderBy) || strings.Join(primaryKey, ", ") != strings.Join(orderBy[:len(primaryKey)], ", ") {
+		return &SortingKeyError{PrimaryKey: primaryKey, OrderBy: orderBy}, nil, nil
+	}
+	return nil, primaryKey, orderBy
+}
+
+// renderClickhouseTableHead returns the CREATE TABLE statement up to its ENGINE clause.
+func renderClickhouseTableHead(cluster string, database string, name string, columns []clickhouseColumnDefinition, constraints []string, engine string) string {
+	var definitions []string
+	for _, column := range columns {
+		definition := "    " + quoteClickhouseIdentifier(column.Name) + " " + column.Type
+		if column.Comment != "" {
+			definition += " COMMENT " + quoteClickhouseString(column.Comment)
+		}
+		definitions = append(definitions, definition)
+	}
+	for _, constraint := range constraints {
+		definitions = append(definitions, "    "+constraint)
+	}
+	return "CREATE TABLE " + clickhouseQualifiedName(database, name) + onCluster(cluster) + "\n(\n" +
+		strings.Join(definitions, ",\n") + "\n)\n" +
+		"ENGINE = " + engine + "\n"
+}
+
+func clickhouseQualifiedName(database string, name string) string {
+	if database == "" {
+		return quoteClickhouseIdentifier(name)
+	}
+	return quoteClickhouseIdentifier(database) + "." + quoteClickhouseIdentifier(name)
+}
+
+// clickhouseSetting is a table setting, its value being already rendered.
+type clickhouseSetting struct {
+	Name  string
+	Value string
+}
+
+func renderClickhouseSettings(settings []clickhouseSetting) string {
+	var assignments []string
+	for _, setting := range settings {
+		assignments = append(assignments, setting.Name+" = "+setting.Value)
+	}
+	return "SETTINGS " + strings.Join(assignments, ", ") + "\n"
+}
+
+func (t clickhouseCreateTable) isMergeTree() bool {
+	engineName, _, _ := strings.Cut(t.Engine, "(")
+	return isMergeTreeEngine(strings.TrimSpace(engineName))
+}
+
+func isMergeTreeEngine(engineName string) bool {
+	return strings.HasSuffix(engineName, ClickhouseEngineMergeTree)
+}
+
+func quoteClickhouseIdentifier(name string) string {
+	return "`" + strings.NewReplacer(`\`, `\\`, "`", "\\`").Replace(name) + "`"
+}
+
+// clickhouseTuple returns the expressions as a tuple, tuple() being the empty one.
+func clickhouseTuple(expressions []string) string {
+	if len(expressions) == 0 {
+		return "tuple()"
+	}
+	if len(expressions) == 1 {
+		return expressions[0]
+	}
+	return "(" + strings.Join(expressions, ", ") + ")"
+}
+
+// clickhouseSettingValue renders numbers as is and quotes any other value.
+func clickhouseSettingValue(value string) string {
+	if regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`).MatchString(value) {
+		return value
+	}
+	return quoteClickhouseString(value)
+}
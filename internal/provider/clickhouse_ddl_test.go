@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestClickhouseCreateTableRender(t *testing.T) {
+	table := clickhouseCreateTable{
+		clickhouseTableOptions: clickhouseTableOptions{
+			Engine:  "ReplacingMergeTree(`updated_at`)",
+			OrderBy: []string{"`tenant`", "`id`", "`updated_at`"},
+		},
+		Name: "weird`name",
+		Columns: []clickhouseColumnDefinition{
+			{Name: "tenant", Type: "String"},
+			{Name: "id", Type: "Int64"},
+			{Name: "updated_at", Type: "DateTime64(6, 'UTC')"},
+		},
+		Constraints: []string{"CONSTRAINT `id_positive` CHECK `id` > 0"},
+		PrimaryKey:  []string{"tenant", "id"},
+	}
+	expected := "CREATE TABLE `weird\\`name`\n(\n" +
+		"    `tenant` String,\n" +
+		"    `id` Int64,\n" +
+		"    `updated_at` DateTime64(6, 'UTC'),\n" +
+		"    CONSTRAINT `id_positive` CHECK `id` > 0\n" +
+		")\n" +
+		"ENGINE = ReplacingMergeTree(`updated_at`)\n" +
+		"PRIMARY KEY (`tenant`, `id`)\n" +
+		"ORDER BY (`tenant`, `id`, `updated_at`)\n"
+	if _, got := table.render(); got != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, got)
+	}
+
+	table = clickhouseCreateTable{
+		clickhouseTableOptions: clickhouseTableOptions{Engine: "Log"},
+		Name:                   "events",
+		Columns:                []clickhouseColumnDefinition{{Name: "id", Type: "Int64"}},
+		PrimaryKey:             []string{"id"},
+	}
+	expected = "CREATE TABLE `events`\n(\n    `id` Int64\n)\nENGINE = Log\n"
+	if _, got := table.render(); got != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, got)
+	}
+}
+
+func TestClickhouseCreateTableSortingKey(t *testing.T) {
+	table := clickhouseCreateTable{
+		clickhouseTableOptions: clickhouseTableOptions{
+			Engine:  ClickhouseEngineMergeTree,
+			OrderBy: []string{"id"},
+		},
+		Name:       "events",
+		Columns:    []clickhouseColumnDefinition{{Name: "id", Type: "Int64"}},
+		PrimaryKey: []string{"id"},
+	}
+	expected := "CREATE TABLE `events`\n(\n    `id` Int64\n)\nENGINE = MergeTree\nORDER BY `id`\n"
+	if _, got := table.render(); got != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, got)
+	}
+
+	table.OrderBy = []string{"created_at", "`id`"}
+	err, _ := table.render()
+	if _, ok := err.(*SortingKeyError); !ok {
+		t.Fatalf("expected a sorting key error, got %v", err)
+	}
+	if expected := "Primary key `id` must be a prefix of the sorting key (`created_at`, `id`)"; err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
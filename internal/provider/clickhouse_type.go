@@ -0,0 +1,353 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// clickhouseTypeArguments is the kind of arguments a Clickhouse data type accepts.
+type clickhouseTypeArguments int
+
+const (
+	clickhouseTypeNoArguments clickhouseTypeArguments = iota
+	// Data types, e.g. Array(T) or Map(K, V)
+	clickhouseTypeTypes
+	// Data types optionally prefixed by a name, e.g. Tuple(a String, b Int32)
+	clickhouseTypeNamedTypes
+	// Unsigned integers, e.g. FixedString(N) or Decimal(P, S)
+	clickhouseTypeNumbers
+	// An unsigned integer precision followed by an optional timezone, e.g. DateTime64(3, 'UTC')
+	clickhouseTypePrecision
+	// An optional timezone, e.g. DateTime('UTC')
+	clickhouseTypeTimezone
+	// Enum labels with optional values, e.g. Enum8('a' = 1, 'b' = 2)
+	clickhouseTypeEnumLabels
+	// Arguments not validated, e.g. JSON(max_dynamic_paths = 10) or AggregateFunction(uniq, UInt64)
+	clickhouseTypeAnyArguments
+)
+
+type clickhouseTypeSignature struct {
+	arguments    clickhouseTypeArguments
+	minArguments int
+	maxArguments int
+}
+
+// clickhouseTypeSignatures lists the Clickhouse data types and the number of arguments they accept,
+// -1 standing for any number of arguments.
+var clickhouseTypeSignatures = map[string]clickhouseTypeSignature{
+	"Int8":                    {clickhouseTypeNoArguments, 0, 0},
+	"Int16":                   {clickhouseTypeNoArguments, 0, 0},
+	"Int32":                   {clickhouseTypeNoArguments, 0, 0},
+	"Int64":                   {clickhouseTypeNoArguments, 0, 0},
+	"Int128":                  {clickhouseTypeNoArguments, 0, 0},
+	"Int256":                  {clickhouseTypeNoArguments, 0, 0},
+	"UInt8":                   {clickhouseTypeNoArguments, 0, 0},
+	"UInt16":                  {clickhouseTypeNoArguments, 0, 0},
+	"UInt32":                  {clickhouseTypeNoArguments, 0, 0},
+	"UInt64":                  {clickhouseTypeNoArguments, 0, 0},
+	"UInt128":                 {clickhouseTypeNoArguments, 0, 0},
+	"UInt256":                 {clickhouseTypeNoArguments, 0, 0},
+	"Float32":                 {clickhouseTypeNoArguments, 0, 0},
+	"Float64":                 {clickhouseTypeNoArguments, 0, 0},
+	"BFloat16":                {clickhouseTypeNoArguments, 0, 0},
+	"Bool":                    {clickhouseTypeNoArguments, 0, 0},
+	"String":                  {clickhouseTypeNoArguments, 0, 0},
+	"UUID":                    {clickhouseTypeNoArguments, 0, 0},
+	"IPv4":                    {clickhouseTypeNoArguments, 0, 0},
+	"IPv6":                    {clickhouseTypeNoArguments, 0, 0},
+	"Date":                    {clickhouseTypeNoArguments, 0, 0},
+	"Date32":                  {clickhouseTypeNoArguments, 0, 0},
+	"Time":                    {clickhouseTypeNoArguments, 0, 0},
+	"Point":                   {clickhouseTypeNoArguments, 0, 0},
+	"Ring":                    {clickhouseTypeNoArguments, 0, 0},
+	"LineString":              {clickhouseTypeNoArguments, 0, 0},
+	"MultiLineString":         {clickhouseTypeNoArguments, 0, 0},
+	"Polygon":                 {clickhouseTypeNoArguments, 0, 0},
+	"MultiPolygon":            {clickhouseTypeNoArguments, 0, 0},
+	"Dynamic":                 {clickhouseTypeAnyArguments, 0, -1},
+	"Nullable":                {clickhouseTypeTypes, 1, 1},
+	"LowCardinality":          {clickhouseTypeTypes, 1, 1},
+	"Array":                   {clickhouseTypeTypes, 1, 1},
+	"Map":                     {clickhouseTypeTypes, 2, 2},
+	"Variant":                 {clickhouseTypeTypes, 1, -1},
+	"Tuple":                   {clickhouseTypeNamedTypes, 1, -1},
+	"Nested":                  {clickhouseTypeNamedTypes, 1, -1},
+	"FixedString":             {clickhouseTypeNumbers, 1, 1},
+	"Decimal":                 {clickhouseTypeNumbers, 1, 2},
+	"Decimal32":               {clickhouseTypeNumbers, 1, 1},
+	"Decimal64":               {clickhouseTypeNumbers, 1, 1},
+	"Decimal128":              {clickhouseTypeNumbers, 1, 1},
+	"Decimal256":              {clickhouseTypeNumbers, 1, 1},
+	"DateTime64":              {clickhouseTypePrecision, 1, 2},
+	"Time64":                  {clickhouseTypePrecision, 1, 1},
+	"DateTime":                {clickhouseTypeTimezone, 0, 1},
+	"Enum8":                   {clickhouseTypeEnumLabels, 1, -1},
+	"Enum16":                  {clickhouseTypeEnumLabels, 1, -1},
+	"Enum":                    {clickhouseTypeEnumLabels, 1, -1},
+	"JSON":                    {clickhouseTypeAnyArguments, 0, -1},
+	"AggregateFunction":       {clickhouseTypeAnyArguments, 1, -1},
+	"SimpleAggregateFunction": {clickhouseTypeAnyArguments, 1, -1},
+}
+
+type ClickhouseTypeSyntaxError struct {
+	ClickhouseType string
+	Position       int
+	Message        string
+}
+
+func (e *ClickhouseTypeSyntaxError) Error() string {
+	return fmt.Sprintf("Clickhouse type %s is invalid at position %d: %s", e.ClickhouseType, e.Position, e.Message)
+}
+
+// clickhouseTypeParser is a recursive descent parser of Clickhouse data types.
+type clickhouseTypeParser struct {
+	input    string
+	position int
+}
+
+// parseClickhouseType checks clickhouseType against the Clickhouse data type grammar,
+// e.g. LowCardinality(Nullable(String)) or Tuple(lower Nullable(Int32), bounds String).
+func parseClickhouseType(clickhouseType string) error {
+	p := &clickhouseTypeParser{input: clickhouseType}
+	p.skipSpaces()
+	if err := p.parseType(); err != nil {
+		return err
+	}
+	p.skipSpaces()
+	if p.position < len(p.input) {
+		return p.errorf("unexpected %q", p.input[p.position:])
+	}
+	return nil
+}
+
+func (p *clickhouseTypeParser) errorf(format string, a ...any) error {
+	return &ClickhouseTypeSyntaxError{
+		ClickhouseType: p.input,
+		Position:       p.position + 1,
+		Message:        fmt.Sprintf(format, a...),
+	}
+}
+
+func (p *clickhouseTypeParser) parseType() error {
+	start := p.position
+	name := p.identifier()
+	if name == "" {
+		return p.errorf("expected a type")
+	}
+	signature, ok := clickhouseTypeSignatures[name]
+	if !ok {
+		p.position = start
+		return p.errorf("unknown type %s", name)
+	}
+	p.skipSpaces()
+	if !p.consume('(') {
+		if signature.minArguments > 0 {
+			return p.errorf("%s expects arguments", name)
+		}
+		return nil
+	}
+	if signature.arguments == clickhouseTypeNoArguments {
+		return p.errorf("%s takes no arguments", name)
+	}
+	if signature.arguments == clickhouseTypeAnyArguments {
+		return p.skipArguments()
+	}
+
+	count := 0
+	for {
+		p.skipSpaces()
+		var err error
+		switch {
+		case signature.arguments == clickhouseTypeTypes:
+			err = p.parseType()
+		case signature.arguments == clickhouseTypeNamedTypes:
+			err = p.parseNamedType(name == "Nested")
+		case signature.arguments == clickhouseTypeNumbers:
+			err = p.parseNumber()
+		case signature.arguments == clickhouseTypePrecision && count == 0:
+			err = p.parseNumber()
+		case signature.arguments == clickhouseTypePrecision, signature.arguments == clickhouseTypeTimezone:
+			err = p.parseString()
+		case signature.arguments == clickhouseTypeEnumLabels:
+			err = p.parseEnumLabel()
+		}
+		if err != nil {
+			return err
+		}
+		count++
+		p.skipSpaces()
+		if p.consume(')') {
+			break
+		}
+		if !p.consume(',') {
+			return p.errorf("expected , or )")
+		}
+	}
+	if count < signature.minArguments || (signature.maxArguments >= 0 && count > signature.maxArguments) {
+		return p.errorf("%s doesn't accept %d arguments", name, count)
+	}
+	return nil
+}
+
+// parseNamedType parses a tuple element, the name being optional unless required is set.
+func (p *clickhouseTypeParser) parseNamedType(required bool) error {
+	start := p.position
+	if p.peek() == '`' {
+		if err := p.parseQuoted('`'); err != nil {
+			return err
+		}
+		p.skipSpaces()
+		return p.parseType()
+	}
+	p.identifier()
+	p.skipSpaces()
+	if isClickhouseIdentifierStart(p.peek()) {
+		return p.parseType()
+	}
+	p.position = start
+	if required {
+		return p.errorf("expected a named element")
+	}
+	return p.parseType()
+}
+
+func (p *clickhouseTypeParser) parseEnumLabel() error {
+	if err := p.parseString(); err != nil {
+		return err
+	}
+	p.skipSpaces()
+	if !p.consume('=') {
+		return nil
+	}
+	p.skipSpaces()
+	p.consume('-')
+	return p.parseNumber()
+}
+
+func (p *clickhouseTypeParser) parseNumber() error {
+	start := p.position
+	for p.position < len(p.input) && p.input[p.position] >= '0' && p.input[p.position] <= '9' {
+		p.position++
+	}
+	if p.position == start {
+		return p.errorf("expected a number")
+	}
+	return nil
+}
+
+func (p *clickhouseTypeParser) parseString() error {
+	if p.peek() != '\'' {
+		return p.errorf("expected a string")
+	}
+	return p.parseQuoted('\'')
+}
+
+func (p *clickhouseTypeParser) parseQuoted(quote byte) error {
+	p.position++
+	for p.position < len(p.input) {
+		switch p.input[p.position] {
+		case '\\':
+			p.position += 2
+		case quote:
+			p.position++
+			// A doubled quote is an escaped quote
+			if p.peek() != quote {
+				return nil
+			}
+			p.position++
+		default:
+			p.position++
+		}
+	}
+	return p.errorf("unterminated %c", quote)
+}
+
+// skipArguments skips the arguments up to the matching closing parenthesis.
+func (p *clickhouseTypeParser) skipArguments() error {
+	depth := 1
+	for p.position < len(p.input) {
+		switch p.input[p.position] {
+		case '\'', '`':
+			if err := p.parseQuoted(p.input[p.position]); err != nil {
+				return err
+			}
+			continue
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		p.position++
+		if depth == 0 {
+			return nil
+		}
+	}
+	return p.errorf("expected )")
+}
+
+func (p *clickhouseTypeParser) identifier() string {
+	start := p.position
+	if !isClickhouseIdentifierStart(p.peek()) {
+		return ""
+	}
+	for p.position < len(p.input) && (isClickhouseIdentifierStart(p.input[p.position]) || (p.input[p.position] >= '0' && p.input[p.position] <= '9')) {
+		p.position++
+	}
+	return p.input[start:p.position]
+}
+
+func (p *clickhouseTypeParser) peek() byte {
+	if p.position < len(p.input) {
+		return p.input[p.position]
+	}
+	return 0
+}
+
+func (p *clickhouseTypeParser) consume(c byte) bool {
+	if p.peek() == c {
+		p.position++
+		return true
+	}
+	return false
+}
+
+func (p *clickhouseTypeParser) skipSpaces() {
+	for p.position < len(p.input) && (p.input[p.position] == ' ' || p.input[p.position] == '\t' || p.input[p.position] == '\n') {
+		p.position++
+	}
+}
+
+func isClickhouseIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+var _ validator.String = clickhouseTypeValidator{}
+
+// clickhouseTypeValidator validates that a string attribute is a Clickhouse data type.
+type clickhouseTypeValidator struct{}
+
+func (v clickhouseTypeValidator) Description(ctx context.Context) string {
+	return "value must be a valid Clickhouse data type"
+}
+
+func (v clickhouseTypeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v clickhouseTypeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if err := parseClickhouseType(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Clickhouse Type",
+			err.Error(),
+		)
+	}
+}
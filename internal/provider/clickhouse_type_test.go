@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestParseClickhouseType(t *testing.T) {
+	testCases := map[string]bool{
+		"UInt64":                                       true,
+		"LowCardinality(String)":                       true,
+		"LowCardinality(Nullable(String))":             true,
+		"Array(Array(Nullable(DateTime64(6, 'UTC'))))": true,
+		"Decimal(12, 2)":                               true,
+		"FixedString(16)":                              true,
+		"DateTime":                                     true,
+		"DateTime('Europe/Paris')":                     true,
+		"Map(String, Nullable(String))":                true,
+		"Tuple(String, Int32)":                         true,
+		"Tuple(lower Nullable(Int32), upper Nullable(Int32), bounds String)": true,
+		"Tuple(`first name` String)":                                         true,
+		"Enum8('it''s' = 1, 'b' = -2)":                                       true,
+		"JSON(max_dynamic_paths = 10, a.b UInt32)":                           true,
+		"AggregateFunction(uniq, UInt64)":                                    true,
+		"Uint64":                                                             false,
+		"String(10)":                                                         false,
+		"Nullable":                                                           false,
+		"Nullable(String":                                                    false,
+		"Nullable(String, Int32)":                                            false,
+		"Map(String)":                                                        false,
+		"Decimal(P, S)":                                                      false,
+		"DateTime64('UTC')":                                                  false,
+		"Enum8(a = 1)":                                                       false,
+		"Nested(String)":                                                     false,
+		"Array(String) Int32":                                                false,
+		"":                                                                   false,
+	}
+
+	for clickhouseType, valid := range testCases {
+		err := parseClickhouseType(clickhouseType)
+		if valid && err != nil {
+			t.Errorf("%s: expected a valid type, got %s", clickhouseType, err)
+		}
+		if !valid && err == nil {
+			t.Errorf("%s: expected an invalid type", clickhouseType)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ClickhouseCluster describes the cluster the tables are created on.
+type ClickhouseCluster struct {
+	Name             types.String `tfsdk:"name"`
+	ZookeeperPath    types.String `tfsdk:"zookeeper_path"`
+	ReplicaName      types.String `tfsdk:"replica_name"`
+	LocalTableSuffix types.String `tfsdk:"local_table_suffix"`
+	ShardingKey      types.String `tfsdk:"sharding_key"`
+}
+
+// Default replication settings, the {shard} and {replica} macros being defined in the server
+// configuration while {database} and {table} are expanded by Clickhouse on table creation.
+const (
+	ClickhouseClusterDefaultZookeeperPath    = "/clickhouse/tables/{shard}/{database}/{table}"
+	ClickhouseClusterDefaultReplicaName      = "{replica}"
+	ClickhouseClusterDefaultLocalTableSuffix = "_local"
+)
+
+// clickhouseClusterOptions are the cluster options with their defaults applied.
+type clickhouseClusterOptions struct {
+	Name             string
+	ZookeeperPath    string
+	ReplicaName      string
+	LocalTableSuffix string
+	ShardingKey      string
+}
+
+func (c *ClickhouseCluster) options() *clickhouseClusterOptions {
+	if c == nil {
+		return nil
+	}
+	options := &clickhouseClusterOptions{
+		Name:             c.Name.ValueString(),
+		ZookeeperPath:    ClickhouseClusterDefaultZookeeperPath,
+		ReplicaName:      ClickhouseClusterDefaultReplicaName,
+		LocalTableSuffix: ClickhouseClusterDefaultLocalTableSuffix,
+		ShardingKey:      c.ShardingKey.ValueString(),
+	}
+	if !c.ZookeeperPath.IsNull() {
+		options.ZookeeperPath = c.ZookeeperPath.ValueString()
+	}
+	if !c.ReplicaName.IsNull() {
+		options.ReplicaName = c.ReplicaName.ValueString()
+	}
+	if !c.LocalTableSuffix.IsNull() {
+		options.LocalTableSuffix = c.LocalTableSuffix.ValueString()
+	}
+	return options
+}
+
+// replicatedEngine returns the replicated variant of a MergeTree family engine, its
+// ZooKeeper path and replica name coming first, e.g. ReplacingMergeTree(`_version`)
+// becomes ReplicatedReplacingMergeTree('<path>', '<replica>', `_version`). Other engines
+// and already replicated ones are returned as is.
+func (c clickhouseClusterOptions) replicatedEngine(engine string) string {
+	engineName, engineArguments, _ := strings.Cut(engine, "(")
+	engineName = strings.TrimSpace(engineName)
+	if !isMergeTreeEngine(engineName) || strings.HasPrefix(engineName, "Replicated") {
+		return engine
+	}
+	arguments := []string{quoteClickhouseString(c.ZookeeperPath), quoteClickhouseString(c.ReplicaName)}
+	engineArguments = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(engineArguments), ")"))
+	if engineArguments != "" {
+		arguments = append(arguments, engineArguments)
+	}
+	return "Replicated" + engineName + "(" + strings.Join(arguments, ", ") + ")"
+}
+
+// shardingKey returns the sharding key expression, a hash of the primary key columns by
+// default so that the versions of a row land on the same shard, rand() without any.
+func (c clickhouseClusterOptions) shardingKey(primaryKey []string) string {
+	if c.ShardingKey != "" {
+		return c.ShardingKey
+	}
+	if len(primaryKey) == 0 {
+		return "rand()"
+	}
+	var columns []string
+	for _, column := range primaryKey {
+		columns = append(columns, quoteClickhouseIdentifier(column))
+	}
+	return "cityHash64(" + strings.Join(columns, ", ") + ")"
+}
+
+// distributedCreateTable is a Distributed table over the local tables of the cluster.
+type distributedCreateTable struct {
+	Cluster     string
+	Database    string
+	Name        string
+	LocalTable  string
+	ShardingKey string
+}
+
+// render returns the CREATE TABLE statement of the distributed table, its columns
+// being the ones of the local table.
+func (d distributedCreateTable) render() string {
+	database := "currentDatabase()"
+	if d.Database != "" {
+		database = quoteClickhouseString(d.Database)
+	}
+	arguments := []string{quoteClickhouseString(d.Cluster), database, quoteClickhouseString(d.LocalTable), d.ShardingKey}
+	return "CREATE TABLE " + clickhouseQualifiedName(d.Database, d.Name) + onCluster(d.Cluster) +
+		" AS " + clickhouseQualifiedName(d.Database, d.LocalTable) + "\n" +
+		"ENGINE = Distributed(" + strings.Join(arguments, ", ") + ")\n"
+}
+
+// onCluster returns the ON CLUSTER clause of the statements, empty without cluster.
+func onCluster(cluster string) string {
+	if cluster == "" {
+		return ""
+	}
+	return " ON CLUSTER " + quoteClickhouseIdentifier(cluster)
+}
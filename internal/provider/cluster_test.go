@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestClickhouseClusterReplicatedEngine(t *testing.T) {
+	cluster := clickhouseClusterOptions{ZookeeperPath: "/clickhouse/tables/{shard}/{database}/{table}", ReplicaName: "{replica}"}
+	testCases := map[string]string{
+		"MergeTree":   "ReplicatedMergeTree('/clickhouse/tables/{shard}/{database}/{table}', '{replica}')",
+		"MergeTree()": "ReplicatedMergeTree('/clickhouse/tables/{shard}/{database}/{table}', '{replica}')",
+		"ReplacingMergeTree(`_version`, `_is_deleted`)":                "ReplicatedReplacingMergeTree('/clickhouse/tables/{shard}/{database}/{table}', '{replica}', `_version`, `_is_deleted`)",
+		"ReplicatedMergeTree('/custom/{shard}/customer', '{replica}')": "ReplicatedMergeTree('/custom/{shard}/customer', '{replica}')",
+		"Log": "Log",
+	}
+	for engine, expected := range testCases {
+		if got := cluster.replicatedEngine(engine); got != expected {
+			t.Errorf("%s: expected %s, got %s", engine, expected, got)
+		}
+	}
+}
+
+func TestClickhouseClusterShardingKey(t *testing.T) {
+	cluster := clickhouseClusterOptions{}
+	if key := cluster.shardingKey([]string{"tenant", "id"}); key != "cityHash64(`tenant`, `id`)" {
+		t.Errorf("unexpected sharding key %s", key)
+	}
+	if key := cluster.shardingKey(nil); key != "rand()" {
+		t.Errorf("unexpected sharding key %s", key)
+	}
+	cluster.ShardingKey = "`tenant`"
+	if key := cluster.shardingKey([]string{"tenant", "id"}); key != "`tenant`" {
+		t.Errorf("unexpected sharding key %s", key)
+	}
+}
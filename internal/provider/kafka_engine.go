@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// KafkaEngine describes the settings of the generated kafka engine table.
+type KafkaEngine struct {
+	TableName          types.String   `tfsdk:"table_name"`
+	BrokerList         []types.String `tfsdk:"broker_list"`
+	TopicList          []types.String `tfsdk:"topic_list"`
+	GroupName          types.String   `tfsdk:"group_name"`
+	Format             types.String   `tfsdk:"format"`
+	SchemaRegistryUrl  types.String   `tfsdk:"schema_registry_url"`
+	NumConsumers       types.Int64    `tfsdk:"num_consumers"`
+	HandleErrorMode    types.String   `tfsdk:"handle_error_mode"`
+	SkipBrokenMessages types.Int64    `tfsdk:"skip_broken_messages"`
+}
+
+const KafkaEngineFormatAvroConfluent = "AvroConfluent"
+
+const (
+	KafkaEngineHandleErrorModeDefault = "default"
+	KafkaEngineHandleErrorModeStream  = "stream"
+)
+
+// KafkaEngineTableSuffix is appended to the Clickhouse table name to name the kafka engine table.
+const KafkaEngineTableSuffix = "_kafka"
+
+// kafkaEngineOptions are the kafka engine settings with their defaults applied, the table
+// name being resolved once the Clickhouse table name is known.
+type kafkaEngineOptions struct {
+	TableName          string
+	BrokerList         []string
+	TopicList          []string
+	GroupName          string
+	Format             string
+	SchemaRegistryUrl  string
+	NumConsumers       *int64
+	HandleErrorMode    string
+	SkipBrokenMessages *int64
+}
+
+func (k *KafkaEngine) options() *kafkaEngineOptions {
+	if k == nil {
+		return nil
+	}
+	options := &kafkaEngineOptions{
+		TableName:          k.TableName.ValueString(),
+		GroupName:          k.GroupName.ValueString(),
+		Format:             KafkaEngineFormatAvroConfluent,
+		SchemaRegistryUrl:  k.SchemaRegistryUrl.ValueString(),
+		NumConsumers:       k.NumConsumers.ValueInt64Pointer(),
+		HandleErrorMode:    k.HandleErrorMode.ValueString(),
+		SkipBrokenMessages: k.SkipBrokenMessages.ValueInt64Pointer(),
+	}
+	for _, broker := range k.BrokerList {
+		options.BrokerList = append(options.BrokerList, broker.ValueString())
+	}
+	for _, topic := range k.TopicList {
+		options.TopicList = append(options.TopicList, topic.ValueString())
+	}
+	if !k.Format.IsNull() {
+		options.Format = k.Format.ValueString()
+	}
+	return options
+}
+
+// kafkaEngineCreateTable is a kafka engine table reading the columns from the topics.
+type kafkaEngineCreateTable struct {
+	kafkaEngineOptions
+	Cluster  string
+	Database string
+	Columns  []clickhouseColumnDefinition
+}
+
+// render returns the CREATE TABLE statement of the kafka engine table, the optional
+// settings being left to the Clickhouse defaults when unset.
+func (k kafkaEngineCreateTable) render() string {
+	settings := []clickhouseSetting{
+		{Name: "kafka_broker_list", Value: quoteClickhouseString(strings.Join(k.BrokerList, ","))},
+		{Name: "kafka_topic_list", Value: quoteClickhouseString(strings.Join(k.TopicList, ","))},
+		{Name: "kafka_group_name", Value: quoteClickhouseString(k.GroupName)},
+		{Name: "kafka_format", Value: quoteClickhouseString(k.Format)},
+	}
+	if k.SchemaRegistryUrl != "" {
+		settings = append(settings, clickhouseSetting{Name: "format_avro_schema_registry_url", Value: quoteClickhouseString(k.SchemaRegistryUrl)})
+	}
+	if k.NumConsumers != nil {
+		settings = append(settings, clickhouseSetting{Name: "kafka_num_consumers", Value: strconv.FormatInt(*k.NumConsumers, 10)})
+	}
+	if k.HandleErrorMode != "" {
+		settings = append(settings, clickhouseSetting{Name: "kafka_handle_error_mode", Value: quoteClickhouseString(k.HandleErrorMode)})
+	}
+	if k.SkipBrokenMessages != nil {
+		settings = append(settings, clickhouseSetting{Name: "kafka_skip_broken_messages", Value: strconv.FormatInt(*k.SkipBrokenMessages, 10)})
+	}
+	return renderClickhouseTableHead(k.Cluster, k.Database, k.TableName, k.Columns, nil, "Kafka") + renderClickhouseSettings(settings)
+}
@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestKafkaEngineCreateTableRender(t *testing.T) {
+	skipBrokenMessages := int64(10)
+	table := kafkaEngineCreateTable{
+		kafkaEngineOptions: kafkaEngineOptions{
+			TableName:          "customer_kafka",
+			BrokerList:         []string{"kafka:9092"},
+			TopicList:          []string{"customer", "customer_archive"},
+			GroupName:          "group's",
+			Format:             KafkaEngineFormatAvroConfluent,
+			SkipBrokenMessages: &skipBrokenMessages,
+		},
+		Database: "raw",
+		Columns:  []clickhouseColumnDefinition{{Name: "id", Type: "Int64"}},
+	}
+	expected := "CREATE TABLE `raw`.`customer_kafka`\n(\n    `id` Int64\n)\nENGINE = Kafka\n" +
+		"SETTINGS kafka_broker_list = 'kafka:9092', kafka_topic_list = 'customer,customer_archive', kafka_group_name = 'group\\'s', " +
+		"kafka_format = 'AvroConfluent', kafka_skip_broken_messages = 10\n"
+	if got := table.render(); got != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, got)
+	}
+}
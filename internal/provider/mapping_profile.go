@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+// mappingProfileRule is a type mapping rule of a mapping profile file, its keys are
+// the ones of the provider type_mapping rules.
+type mappingProfileRule struct {
+	Type                      *string `yaml:"type"`
+	NumericPrecisionMin       *int64  `yaml:"numeric_precision_min"`
+	NumericPrecisionMax       *int64  `yaml:"numeric_precision_max"`
+	NumericScaleMin           *int64  `yaml:"numeric_scale_min"`
+	NumericScaleMax           *int64  `yaml:"numeric_scale_max"`
+	CharacterMaximumLengthMin *int64  `yaml:"character_maximum_length_min"`
+	CharacterMaximumLengthMax *int64  `yaml:"character_maximum_length_max"`
+	ClickhouseType            *string `yaml:"clickhouse_type"`
+	KafkaEngineType           *string `yaml:"kafkaengine_type"`
+	KafkaEngineExpression     *string `yaml:"kafkaengine_expression"`
+	AthenaType                *string `yaml:"athena_type"`
+}
+
+var mappingProfileRuleKeys = map[string]bool{
+	"type":                         true,
+	"numeric_precision_min":        true,
+	"numeric_precision_max":        true,
+	"numeric_scale_min":            true,
+	"numeric_scale_max":            true,
+	"character_maximum_length_min": true,
+	"character_maximum_length_max": true,
+	"clickhouse_type":              true,
+	"kafkaengine_type":             true,
+	"kafkaengine_expression":       true,
+	"athena_type":                  true,
+}
+
+type MappingProfileError struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (e *MappingProfileError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Message)
+}
+
+// loadMappingProfile reads and compiles the type mapping rules of a YAML or JSON mapping profile file.
+func loadMappingProfile(file string) (error, []TypeMappingRule) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return err, nil
+	}
+	return parseMappingProfile(file, content)
+}
+
+// parseMappingProfile compiles the type mapping rules of a mapping profile, e.g.
+//
+//	type_mapping:
+//	  - type: numeric
+//	    numeric_precision_min: 39
+//	    clickhouse_type: String
+//
+// JSON being a subset of YAML, both formats are parsed the same way. The errors of
+// every invalid rule are reported, one per line, each one with its line in the file.
+func parseMappingProfile(file string, content []byte) (error, []TypeMappingRule) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return fmt.Errorf("%s: %w", file, err), nil
+	}
+	// An empty file has no rules
+	if len(document.Content) == 0 {
+		return nil, nil
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return &MappingProfileError{File: file, Line: root.Line, Message: "expected a mapping with a type_mapping list"}, nil
+	}
+
+	var errs []string
+	var rules []TypeMappingRule
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if key.Value != "type_mapping" {
+			errs = append(errs, (&MappingProfileError{File: file, Line: key.Line, Message: fmt.Sprintf("unknown key %s", key.Value)}).Error())
+			continue
+		}
+		if value.Kind != yaml.SequenceNode {
+			errs = append(errs, (&MappingProfileError{File: file, Line: value.Line, Message: "type_mapping must be a list of rules"}).Error())
+			continue
+		}
+		for _, ruleNode := range value.Content {
+			err, rule := compileMappingProfileRule(file, ruleNode)
+			if err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+			rules = append(rules, rule)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n")), nil
+	}
+	return nil, rules
+}
+
+func compileMappingProfileRule(file string, node *yaml.Node) (error, TypeMappingRule) {
+	ruleError := func(line int, format string, a ...any) error {
+		return &MappingProfileError{File: file, Line: line, Message: fmt.Sprintf(format, a...)}
+	}
+	if node.Kind != yaml.MappingNode {
+		return ruleError(node.Line, "a rule must be a mapping"), TypeMappingRule{}
+	}
+	lines := map[string]int{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		if !mappingProfileRuleKeys[key.Value] {
+			return ruleError(key.Line, "unknown rule key %s", key.Value), TypeMappingRule{}
+		}
+		lines[key.Value] = node.Content[i+1].Line
+	}
+
+	var profileRule mappingProfileRule
+	if err := node.Decode(&profileRule); err != nil {
+		return ruleError(node.Line, "%s", err.Error()), TypeMappingRule{}
+	}
+	if profileRule.Type == nil {
+		return ruleError(node.Line, "rule type is required"), TypeMappingRule{}
+	}
+	if profileRule.ClickhouseType == nil {
+		return ruleError(node.Line, "rule clickhouse_type is required"), TypeMappingRule{}
+	}
+	if err := parseClickhouseType(*profileRule.ClickhouseType); err != nil {
+		return ruleError(lines["clickhouse_type"], "%s", err.Error()), TypeMappingRule{}
+	}
+	if profileRule.KafkaEngineType != nil {
+		if err := parseClickhouseType(*profileRule.KafkaEngineType); err != nil {
+			return ruleError(lines["kafkaengine_type"], "%s", err.Error()), TypeMappingRule{}
+		}
+	}
+
+	ruleModel := TypeMappingRuleModel{
+		Type:                      types.StringPointerValue(profileRule.Type),
+		NumericPrecisionMin:       types.Int64PointerValue(profileRule.NumericPrecisionMin),
+		NumericPrecisionMax:       types.Int64PointerValue(profileRule.NumericPrecisionMax),
+		NumericScaleMin:           types.Int64PointerValue(profileRule.NumericScaleMin),
+		NumericScaleMax:           types.Int64PointerValue(profileRule.NumericScaleMax),
+		CharacterMaximumLengthMin: types.Int64PointerValue(profileRule.CharacterMaximumLengthMin),
+		CharacterMaximumLengthMax: types.Int64PointerValue(profileRule.CharacterMaximumLengthMax),
+		ClickhouseType:            types.StringPointerValue(profileRule.ClickhouseType),
+		KafkaEngineType:           types.StringPointerValue(profileRule.KafkaEngineType),
+		KafkaEngineExpression:     types.StringPointerValue(profileRule.KafkaEngineExpression),
+		AthenaType:                types.StringPointerValue(profileRule.AthenaType),
+	}
+	err, rule := ruleModel.compile()
+	if err != nil {
+		return ruleError(lines["type"], "invalid type pattern %s: %s", *profileRule.Type, err.Error()), rule
+	}
+	return nil, rule
+}
@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMappingProfile(t *testing.T) {
+	testCases := map[string]string{
+		"yaml": `
+type_mapping:
+  - type: numeric
+    numeric_precision_min: 39
+    clickhouse_type: String
+  - type: int8
+    clickhouse_type: UInt64
+    kafkaengine_expression: toUInt64({column})
+`,
+		"json": `{
+  "type_mapping": [
+    {"type": "numeric", "numeric_precision_min": 39, "clickhouse_type": "String"},
+    {"type": "int8", "clickhouse_type": "UInt64", "kafkaengine_expression": "toUInt64({column})"}
+  ]
+}`,
+	}
+
+	for name, content := range testCases {
+		err, rules := parseMappingProfile(name, []byte(content))
+		if err != nil {
+			t.Fatalf("%s: unexpected error %s", name, err)
+		}
+		if len(rules) != 2 {
+			t.Fatalf("%s: expected 2 rules, got %d", name, len(rules))
+		}
+		numeric := psqlColumnDefinition{Type: "numeric", NumericPrecision: 50, HasNumericPrecision: true}
+		if !rules[0].matches(numeric) {
+			t.Errorf("%s: expected numeric(50) to match the first rule", name)
+		}
+		numeric.NumericPrecision = 12
+		if rules[0].matches(numeric) {
+			t.Errorf("%s: expected numeric(12) not to match the first rule", name)
+		}
+		if rules[1].ClickhouseType != "UInt64" || rules[1].KafkaEngineExpression != "toUInt64({column})" {
+			t.Errorf("%s: unexpected second rule %+v", name, rules[1])
+		}
+	}
+}
+
+func TestParseMappingProfileErrors(t *testing.T) {
+	content := `type_mapping:
+  - type: numeric
+    clickhouse_type: Decimal(P, S)
+  - type: "int8("
+    clickhouse_type: UInt64
+  - type: text
+    clickhouse_typ: String
+  - clickhouse_type: String
+`
+	err, _ := parseMappingProfile("mappings.yaml", []byte(content))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, expected := range []string{
+		"mappings.yaml:3: Clickhouse type Decimal(P, S) is invalid",
+		"mappings.yaml:4: invalid type pattern int8(",
+		"mappings.yaml:7: unknown rule key clickhouse_typ",
+		"mappings.yaml:8: rule type is required",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected %q in %q", expected, err.Error())
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// MaterializedView describes the materialized view moving the kafka engine rows to the Clickhouse table.
+type MaterializedView struct {
+	Name           types.String            `tfsdk:"name"`
+	Where          []types.String          `tfsdk:"where"`
+	VirtualColumns map[string]types.String `tfsdk:"virtual_columns"`
+}
+
+// MaterializedViewSuffix is appended to the Clickhouse table name to name the materialized view.
+const MaterializedViewSuffix = "_mv"
+
+// kafkaEngineVirtualColumns are the virtual columns of the kafka engine tables, in their
+// rendering order, with the type of the audit column they are mapped to.
+var kafkaEngineVirtualColumns = []ClickhouseColumn{
+	{Name: types.StringValue("_topic"), Type: types.StringValue("LowCardinality(String)")},
+	{Name: types.StringValue("_key"), Type: types.StringValue("String")},
+	{Name: types.StringValue("_partition"), Type: types.StringValue("UInt64")},
+	{Name: types.StringValue("_offset"), Type: types.StringValue("UInt64")},
+	{Name: types.StringValue("_timestamp"), Type: types.StringValue("Nullable(DateTime)")},
+	{Name: types.StringValue("_timestamp_ms"), Type: types.StringValue("Nullable(DateTime64(3))")},
+}
+
+func kafkaEngineVirtualColumnNames() []string {
+	var names []string
+	for _, column := range kafkaEngineVirtualColumns {
+		names = append(names, column.Name.ValueString())
+	}
+	return names
+}
+
+// materializedViewOptions are the materialized view options, the view name being resolved
+// once the Clickhouse table name is known.
+type materializedViewOptions struct {
+	Name           string
+	Where          []string
+	VirtualColumns map[string]string
+}
+
+func (m *MaterializedView) options() *materializedViewOptions {
+	if m == nil {
+		return nil
+	}
+	options := &materializedViewOptions{Name: m.Name.ValueString()}
+	for _, condition := range m.Where {
+		options.Where = append(options.Where, condition.ValueString())
+	}
+	if m.VirtualColumns != nil {
+		options.VirtualColumns = map[string]string{}
+		for virtualColumn, auditColumn := range m.VirtualColumns {
+			options.VirtualColumns[virtualColumn] = auditColumn.ValueString()
+		}
+	}
+	return options
+}
+
+// auditConversion returns the conversion of the audit columns the virtual columns are mapped to.
+func (m materializedViewOptions) auditConversion() (error, psqlColumnConversion) {
+	var conversion psqlColumnConversion
+	for _, virtualColumn := range kafkaEngineVirtualColumns {
+		auditColumn, ok := m.VirtualColumns[virtualColumn.Name.ValueString()]
+		if !ok {
+			continue
+		}
+		err, athenaType := clickhouseToAthena(virtualColumn.Type.ValueString())
+		if err != nil {
+			return err, conversion
+		}
+		conversion.ClickhouseColumns = append(conversion.ClickhouseColumns, ClickhouseColumn{
+			Name: types.StringValue(auditColumn),
+			Type: virtualColumn.Type,
+		})
+		conversion.KafkaEngineColumnsMapping = append(conversion.KafkaEngineColumnsMapping, types.StringValue(virtualColumn.Name.ValueString()+" as "+quoteClickhouseIdentifier(auditColumn)))
+		conversion.AthenaColumns = append(conversion.AthenaColumns, AthenaColumn{
+			Name: types.StringValue(auditColumn),
+			Type: types.StringValue(athenaType),
+		})
+	}
+	return nil, conversion
+}
+
+// materializedViewCreate is a materialized view inserting the mapped kafka engine rows into the table.
+type materializedViewCreate struct {
+	materializedViewOptions
+	Cluster          string
+	Database         string
+	TableName        string
+	KafkaEngineTable string
+	Mapping          []string
+}
+
+// render returns the CREATE MATERIALIZED VIEW statement, several where conditions being joined with AND.
+func (m materializedViewCreate) render() string {
+	var expressions []string
+	for _, expression := range m.Mapping {
+		expressions = append(expressions, "    "+expression)
+	}
+	statement := "CREATE MATERIALIZED VIEW " + clickhouseQualifiedName(m.Database, m.Name) + onCluster(m.Cluster) +
+		" TO " + clickhouseQualifiedName(m.Database, m.TableName) + "\n" +
+		"AS SELECT\n" + strings.Join(expressions, ",\n") + "\n" +
+		"FROM " + clickhouseQualifiedName(m.Database, m.KafkaEngineTable) + "\n"
+	if len(m.Where) == 1 {
+		statement += "WHERE " + m.Where[0] + "\n"
+	} else if len(m.Where) > 1 {
+		var conditions []string
+		for _, condition := range m.Where {
+			conditions = append(conditions, "("+condition+")")
+		}
+		statement += "WHERE " + strings.Join(conditions, " AND ") + "\n"
+	}
+	return statement
+}
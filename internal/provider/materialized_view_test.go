@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestMaterializedViewCreateRender(t *testing.T) {
+	view := materializedViewCreate{
+		materializedViewOptions: materializedViewOptions{
+			Name:  "customer_mv",
+			Where: []string{"isNotNull(`id`)", "`__op` != 'd'"},
+		},
+		TableName:        "customer",
+		KafkaEngineTable: "customer_kafka",
+		Mapping:          []string{"`id`", "_offset as `_kafka_offset`"},
+	}
+	expected := "CREATE MATERIALIZED VIEW `customer_mv` TO `customer`\n" +
+		"AS SELECT\n    `id`,\n    _offset as `_kafka_offset`\n" +
+		"FROM `customer_kafka`\n" +
+		"WHERE (isNotNull(`id`)) AND (`__op` != 'd')\n"
+	if got := view.render(); got != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, got)
+	}
+}
+
+func TestMaterializedViewAuditConversion(t *testing.T) {
+	options := materializedViewOptions{VirtualColumns: map[string]string{
+		"_timestamp_ms": "_kafka_timestamp",
+		"_topic":        "_kafka_topic",
+	}}
+	err, conversion := options.auditConversion()
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if len(conversion.ClickhouseColumns) != 2 || conversion.ClickhouseColumns[0].Name.ValueString() != "_kafka_topic" {
+		t.Fatalf("expected the audit columns in the virtual columns order, got %+v", conversion.ClickhouseColumns)
+	}
+	if conversion.ClickhouseColumns[1].Type.ValueString() != "Nullable(DateTime64(3))" {
+		t.Errorf("unexpected type %s", conversion.ClickhouseColumns[1].Type.ValueString())
+	}
+}
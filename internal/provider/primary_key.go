@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PrimaryKeyGuessing describes how the primary key is guessed from the column names.
+type PrimaryKeyGuessing struct {
+	Enabled           types.Bool     `tfsdk:"enabled"`
+	Patterns          []types.String `tfsdk:"patterns"`
+	PreferNonNullable types.Bool     `tfsdk:"prefer_non_nullable"`
+}
+
+// PrimaryKeyGuessingTablePlaceholder is replaced by the quoted table name in the guessing patterns.
+const PrimaryKeyGuessingTablePlaceholder = "{table}"
+
+// defaultPrimaryKeyGuessingPatterns are tried in order, the table aware ones first.
+var defaultPrimaryKeyGuessingPatterns = []string{
+	"^" + PrimaryKeyGuessingTablePlaceholder + "_id$",
+	"^id$",
+	"_id$",
+}
+
+// compilePrimaryKeyGuessingPatterns compiles the patterns for the table, the table aware
+// patterns being left out when the table name is unknown. It returns the index of the
+// pattern failing to compile with its error.
+func compilePrimaryKeyGuessingPatterns(patterns []string, tableName string) (error, int, []*regexp.Regexp) {
+	var compiledPatterns []*regexp.Regexp
+	for i, pattern := range patterns {
+		if strings.Contains(pattern, PrimaryKeyGuessingTablePlaceholder) {
+			if tableName == "" {
+				continue
+			}
+			pattern = strings.ReplaceAll(pattern, PrimaryKeyGuessingTablePlaceholder, regexp.QuoteMeta(tableName))
+		}
+		compiledPattern, err := regexp.Compile(pattern)
+		if err != nil {
+			return err, i, nil
+		}
+		compiledPatterns = append(compiledPatterns, compiledPattern)
+	}
+	return nil, -1, compiledPatterns
+}
+
+// guessPrimaryKey returns the index of the column guessed as primary key, or -1. The
+// columns matching the first pattern with matches are candidates, the first candidate
+// being picked unless preferNonNullable is set, in which case non-nullable integer or
+// uuid columns come first, then non-nullable columns.
+func guessPrimaryKey(columns []psqlColumnDefinition, patterns []*regexp.Regexp, preferNonNullable bool) int {
+	for _, pattern := range patterns {
+		guess := -1
+		guessScore := -1
+		for i, column := range columns {
+			if !pattern.MatchString(column.Name) {
+				continue
+			}
+			score := 0
+			if preferNonNullable {
+				score = primaryKeyScore(column)
+			}
+			if score > guessScore {
+				guess = i
+				guessScore = score
+			}
+		}
+		if guess >= 0 {
+			return guess
+		}
+	}
+	return -1
+}
+
+func primaryKeyScore(column psqlColumnDefinition) int {
+	score := 0
+	if !column.IsNullable {
+		score += 2
+	}
+	switch column.Type {
+	case "int2", "int4", "int8", "uuid":
+		score++
+	}
+	return score
+}
+
+// UniqueConstraint is a PostgreSQL unique constraint of the table.
+type UniqueConstraint struct {
+	Name    types.String   `tfsdk:"name"`
+	Columns []types.String `tfsdk:"columns"`
+}
+
+// uniqueNonNullConstraint returns the index of the first unique constraint on non-nullable
+// columns, which identifies the rows as well as a primary key, or -1.
+func uniqueNonNullConstraint(constraints []UniqueConstraint, columns []psqlColumnDefinition) int {
+	nullable := map[string]bool{}
+	for _, column := range columns {
+		nullable[column.Name] = column.IsNullable
+	}
+	for i, constraint := range constraints {
+		isNonNull := len(constraint.Columns) > 0
+		for _, column := range constraint.Columns {
+			if isNullable, ok := nullable[column.ValueString()]; !ok || isNullable {
+				isNonNull = false
+			}
+		}
+		if isNonNull {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestGuessPrimaryKey(t *testing.T) {
+	columns := []psqlColumnDefinition{
+		{Name: "product_id", Type: "int8", IsNullable: true},
+		{Name: "label", Type: "text", IsNullable: false},
+		{Name: "user_id", Type: "int8", IsNullable: false},
+		{Name: "product_history_change_id", Type: "int8", IsNullable: true},
+	}
+	testCases := []struct {
+		name              string
+		patterns          []string
+		tableName         string
+		preferNonNullable bool
+		expected          int
+	}{
+		{"table aware", defaultPrimaryKeyGuessingPatterns, "product_history_change", true, 3},
+		{"without table name", defaultPrimaryKeyGuessingPatterns, "", true, 2},
+		{"first match", defaultPrimaryKeyGuessingPatterns, "", false, 0},
+		{"custom pattern", []string{"^label$"}, "", true, 1},
+		{"no match", []string{"^id$"}, "", true, -1},
+	}
+
+	for _, testCase := range testCases {
+		err, _, patterns := compilePrimaryKeyGuessingPatterns(testCase.patterns, testCase.tableName)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %s", testCase.name, err)
+		}
+		actual := guessPrimaryKey(columns, patterns, testCase.preferNonNullable)
+		if actual != testCase.expected {
+			t.Errorf("%s: expected column %d, got %d", testCase.name, testCase.expected, actual)
+		}
+	}
+
+	err, i, _ := compilePrimaryKeyGuessingPatterns([]string{"_id$", "(("}, "")
+	if err == nil || i != 1 {
+		t.Errorf("expected an error on pattern 1, got %d", i)
+	}
+}
+
+func TestUniqueNonNullConstraint(t *testing.T) {
+	columns := []psqlColumnDefinition{
+		{Name: "email", IsNullable: true},
+		{Name: "tenant", IsNullable: false},
+		{Name: "sku", IsNullable: false},
+	}
+	constraints := []UniqueConstraint{
+		{Columns: []types.String{types.StringValue("email")}},
+		{Columns: []types.String{types.StringValue("tenant"), types.StringValue("sku")}},
+	}
+	if actual := uniqueNonNullConstraint(constraints, columns); actual != 1 {
+		t.Errorf("expected constraint 1, got %d", actual)
+	}
+	if actual := uniqueNonNullConstraint(constraints[:1], columns); actual != -1 {
+		t.Errorf("expected no constraint, got %d", actual)
+	}
+}
@@ -5,11 +5,16 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure DataToolsProvider satisfies various provider interfaces.
@@ -25,6 +30,17 @@ type DataToolsProvider struct {
 
 // DataToolsProviderModel describes the provider data model.
 type DataToolsProviderModel struct {
+	InetType           types.String           `tfsdk:"inet_type"`
+	Timezone           types.String           `tfsdk:"timezone"`
+	TypeMapping        []TypeMappingRuleModel `tfsdk:"type_mapping"`
+	MappingProfileFile types.String           `tfsdk:"mapping_profile_file"`
+}
+
+// DataToolsProviderData is the provider configuration shared with the data sources.
+type DataToolsProviderData struct {
+	InetType         string
+	Timezone         string
+	TypeMappingRules []TypeMappingRule
 }
 
 func (p *DataToolsProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -34,7 +50,84 @@ func (p *DataToolsProvider) Metadata(ctx context.Context, req provider.MetadataR
 
 func (p *DataToolsProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Attributes: map[string]schema.Attribute{},
+		Attributes: map[string]schema.Attribute{
+			"inet_type": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse type for PostgreSQL inet columns, `IPv4` or `IPv6` (default)",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(InetTypeIPv4, InetTypeIPv6),
+				},
+			},
+			"timezone": schema.StringAttribute{
+				MarkdownDescription: "Timezone of the Clickhouse DateTime64 for PostgreSQL timestamptz columns, `UTC` by default",
+				Optional:            true,
+				Validators: []validator.String{
+					timezoneValidator{},
+				},
+			},
+			"mapping_profile_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a YAML or JSON mapping profile file holding a `type_mapping` list of rules, with the same keys as the provider `type_mapping` rules, consulted after them",
+				Optional:            true,
+			},
+			"type_mapping": schema.ListNestedAttribute{
+				MarkdownDescription: "Type mapping rules consulted in order before the built-in mapping, the first rule matching a column gives its types, the column overrides still taking precedence",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Regular expression matching the whole normalized PostgreSQL type, e.g. `numeric`, `int[248]` or `varchar\\[\\]`, domains being matched on their base type",
+							Required:            true,
+						},
+						"numeric_precision_min": schema.Int64Attribute{
+							MarkdownDescription: "Minimum numeric precision of the matched columns, columns without precision never match",
+							Optional:            true,
+						},
+						"numeric_precision_max": schema.Int64Attribute{
+							MarkdownDescription: "Maximum numeric precision of the matched columns, columns without precision never match",
+							Optional:            true,
+						},
+						"numeric_scale_min": schema.Int64Attribute{
+							MarkdownDescription: "Minimum numeric scale of the matched columns, columns without scale never match",
+							Optional:            true,
+						},
+						"numeric_scale_max": schema.Int64Attribute{
+							MarkdownDescription: "Maximum numeric scale of the matched columns, columns without scale never match",
+							Optional:            true,
+						},
+						"character_maximum_length_min": schema.Int64Attribute{
+							MarkdownDescription: "Minimum character maximum length of the matched columns, columns without length never match",
+							Optional:            true,
+						},
+						"character_maximum_length_max": schema.Int64Attribute{
+							MarkdownDescription: "Maximum character maximum length of the matched columns, columns without length never match",
+							Optional:            true,
+						},
+						"clickhouse_type": schema.StringAttribute{
+							MarkdownDescription: "Clickhouse type of the matched columns, made Nullable for nullable columns",
+							Required:            true,
+							Validators: []validator.String{
+								clickhouseTypeValidator{},
+							},
+						},
+						"kafkaengine_type": schema.StringAttribute{
+							MarkdownDescription: "Kafka engine Clickhouse type of the matched columns, made Nullable for nullable columns",
+							Optional:            true,
+							Validators: []validator.String{
+								clickhouseTypeValidator{},
+							},
+						},
+						"kafkaengine_expression": schema.StringAttribute{
+							MarkdownDescription: "Expression converting the kafka engine column, `{column}` being replaced by the quoted column name, e.g. `toUInt64({column})`",
+							Optional:            true,
+						},
+						"athena_type": schema.StringAttribute{
+							MarkdownDescription: "Athena type of the matched columns",
+							Optional:            true,
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -46,6 +139,38 @@ func (p *DataToolsProvider) Configure(ctx context.Context, req provider.Configur
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	providerData := &DataToolsProviderData{
+		InetType: data.InetType.ValueString(),
+		Timezone: data.Timezone.ValueString(),
+	}
+	for i, ruleModel := range data.TypeMapping {
+		err, rule := ruleModel.compile()
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("type_mapping").AtListIndex(i).AtName("type"),
+				"Invalid Type Mapping Rule",
+				fmt.Sprintf("Unable to compile the type pattern %s: %s", ruleModel.Type.ValueString(), err.Error()),
+			)
+			continue
+		}
+		providerData.TypeMappingRules = append(providerData.TypeMappingRules, rule)
+	}
+	if !data.MappingProfileFile.IsNull() {
+		err, rules := loadMappingProfile(data.MappingProfileFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("mapping_profile_file"),
+				"Invalid Mapping Profile File",
+				"Unable to load the mapping profile file:\n"+err.Error(),
+			)
+		}
+		providerData.TypeMappingRules = append(providerData.TypeMappingRules, rules...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.DataSourceData = providerData
 }
 
 func (p *DataToolsProvider) Resources(ctx context.Context) []func() resource.Resource {
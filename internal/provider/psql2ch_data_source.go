@@ -5,13 +5,23 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"math"
 	"regexp"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -25,29 +35,77 @@ func NewPsql2ChDataSource() datasource.DataSource {
 
 // Psql2ChDataSource defines the data source implementation.
 type Psql2ChDataSource struct {
+	providerData *DataToolsProviderData
 }
 
 // Psql2ChDataSourceModel describes the data source data model.
 type Psql2ChDataSourceModel struct {
-	Id                                  types.String       `tfsdk:"id"`
-	PostgresColumns                     []PsqlColumn       `tfsdk:"postgres_columns"`
-	ClickhousePrimaryKey                []types.String     `tfsdk:"clickhouse_primarykey"`
-	ClickhouseGuessedPrimaryKey         []types.String     `tfsdk:"clickhouse_guessed_primarykey"`
-	ClickhouseColumns                   []ClickhouseColumn `tfsdk:"clickhouse_columns"`
-	ClickhouseKafkaEngineColumns        []ClickhouseColumn `tfsdk:"clickhouse_kafkaengine_columns"`
-	ClickhouseKafkaEngineColumnsMapping types.List         `tfsdk:"clickhouse_kafkaengine_columns_mapping"`
-	AthenaColumns                       []AthenaColumn     `tfsdk:"athena_columns"`
+	Id                                  types.String        `tfsdk:"id"`
+	PostgresColumns                     []PsqlColumn        `tfsdk:"postgres_columns"`
+	ClickhousePrimaryKey                []types.String      `tfsdk:"clickhouse_primarykey"`
+	ClickhouseGuessedPrimaryKey         []types.String      `tfsdk:"clickhouse_guessed_primarykey"`
+	ClickhouseColumns                   []ClickhouseColumn  `tfsdk:"clickhouse_columns"`
+	ClickhouseKafkaEngineColumns        []ClickhouseColumn  `tfsdk:"clickhouse_kafkaengine_columns"`
+	ClickhouseKafkaEngineColumnsMapping types.List          `tfsdk:"clickhouse_kafkaengine_columns_mapping"`
+	AthenaColumns                       []AthenaColumn      `tfsdk:"athena_columns"`
+	JSONStrategy                        types.String        `tfsdk:"json_strategy"`
+	TimeStrategy                        types.String        `tfsdk:"time_strategy"`
+	IntervalStrategy                    types.String        `tfsdk:"interval_strategy"`
+	BinaryHandlingMode                  types.String        `tfsdk:"binary_handling_mode"`
+	ClickhouseConstraints               []types.String      `tfsdk:"clickhouse_constraints"`
+	OnUnsupportedType                   types.String        `tfsdk:"on_unsupported_type"`
+	TableName                           types.String        `tfsdk:"table_name"`
+	PrimaryKeyGuessing                  *PrimaryKeyGuessing `tfsdk:"primary_key_guessing"`
+	SchemaName                          types.String        `tfsdk:"schema_name"`
+	UniqueConstraints                   []UniqueConstraint  `tfsdk:"unique_constraints"`
+	ClickhouseTableName                 types.String        `tfsdk:"clickhouse_table_name"`
+	ClickhouseTable                     *ClickhouseTable    `tfsdk:"clickhouse_table"`
+	ClickhouseCreateTable               types.String        `tfsdk:"clickhouse_create_table"`
+	CDCStrategy                         types.String        `tfsdk:"cdc_strategy"`
+	CDCVersion                          types.String        `tfsdk:"cdc_version"`
+	KafkaEngine                         *KafkaEngine        `tfsdk:"kafka_engine"`
+	ClickhouseKafkaEngineCreateTable    types.String        `tfsdk:"clickhouse_kafkaengine_create_table"`
+	MaterializedView                    *MaterializedView   `tfsdk:"materialized_view"`
+	ClickhouseCreateMaterializedView    types.String        `tfsdk:"clickhouse_create_materialized_view"`
+	Cluster                             *ClickhouseCluster  `tfsdk:"cluster"`
+	ClickhouseCreateDistributedTable    types.String        `tfsdk:"clickhouse_create_distributed_table"`
 }
 
 type PsqlColumn struct {
+	Name                   types.String   `tfsdk:"name"`
+	Type                   types.String   `tfsdk:"type"`
+	IsPrimaryKey           types.Bool     `tfsdk:"is_primary_key"`
+	NumericPrecision       types.Int64    `tfsdk:"numeric_precision"`
+	NumericScale           types.Int64    `tfsdk:"numeric_scale"`
+	CharacterMaximumLength types.Int64    `tfsdk:"character_maximum_length"`
+	DatetimePrecicion      types.Int64    `tfsdk:"datetime_precision"`
+	IsNullable             types.Bool     `tfsdk:"is_nullable"`
+	JSONStrategy           types.String   `tfsdk:"json_strategy"`
+	JSONStruct             types.String   `tfsdk:"json_struct"`
+	InetType               types.String   `tfsdk:"inet_type"`
+	EnumValues             []types.String `tfsdk:"enum_values"`
+	EnumStrategy           types.String   `tfsdk:"enum_strategy"`
+	Fields                 []PsqlField    `tfsdk:"fields"`
+	GeometryType           types.String   `tfsdk:"geometry_type"`
+	GeometryFormat         types.String   `tfsdk:"geometry_format"`
+	RangeStrategy          types.String   `tfsdk:"range_strategy"`
+	BaseType               types.String   `tfsdk:"base_type"`
+	DomainChecks           []types.String `tfsdk:"domain_checks"`
+	Timezone               types.String   `tfsdk:"timezone"`
+	ClickhouseType         types.String   `tfsdk:"clickhouse_type"`
+	KafkaEngineType        types.String   `tfsdk:"kafkaengine_type"`
+	KafkaEngineExpression  types.String   `tfsdk:"kafkaengine_expression"`
+	AthenaType             types.String   `tfsdk:"athena_type"`
+	Comment                types.String   `tfsdk:"comment"`
+}
+
+type PsqlField struct {
 	Name                   types.String `tfsdk:"name"`
 	Type                   types.String `tfsdk:"type"`
-	IsPrimaryKey           types.Bool   `tfsdk:"is_primary_key"`
 	NumericPrecision       types.Int64  `tfsdk:"numeric_precision"`
 	NumericScale           types.Int64  `tfsdk:"numeric_scale"`
 	CharacterMaximumLength types.Int64  `tfsdk:"character_maximum_length"`
 	DatetimePrecicion      types.Int64  `tfsdk:"datetime_precision"`
-	IsNullable             types.Bool   `tfsdk:"is_nullable"`
 }
 
 type ClickhouseColumn struct {
@@ -71,7 +129,7 @@ func (d *Psql2ChDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				MarkdownDescription: "PostgreSQL to Clickhouse converter identifier",
+				MarkdownDescription: "PostgreSQL to Clickhouse converter identifier, a SHA-256 hash of the normalized input changing exactly when the generated schema changes",
 				Computed:            true,
 			},
 			"postgres_columns": schema.ListNestedAttribute{
@@ -84,7 +142,7 @@ func (d *Psql2ChDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 							Required:            true,
 						},
 						"type": schema.StringAttribute{
-							MarkdownDescription: "PostgreSQL Column type",
+							MarkdownDescription: "PostgreSQL Column type, either the udt name (`int4`, `_varchar`) or any PostgreSQL spelling (`integer`, `character varying(255)[]`, `timestamp(3) with time zone`), modifiers are used when the precision, scale and length attributes are absent, time and timestamp types default to a precision of 6 and non numeric modifiers (`geometry(Point,4326)`) are ignored",
 							Required:            true,
 						},
 						"is_primary_key": schema.BoolAttribute{
@@ -111,6 +169,390 @@ func (d *Psql2ChDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 							MarkdownDescription: "True if the column is nullable",
 							Required:            true,
 						},
+						"json_strategy": schema.StringAttribute{
+							MarkdownDescription: "Clickhouse type for json/jsonb column, overrides the data source `json_strategy`",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(JSONStrategyString, JSONStrategyJSON, JSONStrategyMap),
+							},
+						},
+						"json_struct": schema.StringAttribute{
+							MarkdownDescription: "Athena struct declared for json/jsonb column, e.g. `struct<id:int,name:string>`",
+							Optional:            true,
+						},
+						"inet_type": schema.StringAttribute{
+							MarkdownDescription: "Clickhouse type for inet column, `IPv4` or `IPv6`, overrides the provider `inet_type`",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(InetTypeIPv4, InetTypeIPv6),
+							},
+						},
+						"timezone": schema.StringAttribute{
+							MarkdownDescription: "Timezone of the Clickhouse DateTime64 for timestamptz column, overrides the provider `timezone`",
+							Optional:            true,
+							Validators: []validator.String{
+								timezoneValidator{},
+							},
+						},
+						"geometry_type": schema.StringAttribute{
+							MarkdownDescription: "Subtype of the PostGIS geometry/geography column: `Point`, `Ring`, `Polygon` or `MultiPolygon`, the hex encoded WKB is kept in a String when unset",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(GeometryTypePoint, GeometryTypeRing, GeometryTypePolygon, GeometryTypeMultiPolygon),
+							},
+						},
+						"geometry_format": schema.StringAttribute{
+							MarkdownDescription: "Clickhouse representation of the PostGIS geometry/geography column: `Native` (default) for the `geometry_type` geo type or `WKT` for a String",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(GeometryFormatNative, GeometryFormatWKT),
+							},
+						},
+						"range_strategy": schema.StringAttribute{
+							MarkdownDescription: "Clickhouse representation of range column: `Tuple` (default) for `Tuple(lower, upper, bounds)` or `Split` for `<name>_lower` and `<name>_upper` columns, multiranges are always arrays of tuples",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(RangeStrategyTuple, RangeStrategySplit),
+							},
+						},
+						"base_type": schema.StringAttribute{
+							MarkdownDescription: "Base type of the PostgreSQL domain used as column type, e.g. `numeric(12,2)`, the column is converted as its base type",
+							Optional:            true,
+						},
+						"domain_checks": schema.ListAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "CHECK expressions of the PostgreSQL domain, e.g. `VALUE > 0`, rendered in `clickhouse_constraints` with `VALUE` replaced by the column, they have to be valid Clickhouse expressions",
+							Optional:            true,
+						},
+						"clickhouse_type": schema.StringAttribute{
+							MarkdownDescription: "Clickhouse type overriding the computed one, e.g. `UInt64` or `LowCardinality(String)`, it also stands for the kafka engine type of unsupported PostgreSQL types and gives the Athena type unless `athena_type` is set",
+							Optional:            true,
+							Validators: []validator.String{
+								clickhouseTypeValidator{},
+							},
+						},
+						"kafkaengine_type": schema.StringAttribute{
+							MarkdownDescription: "Kafka engine Clickhouse type overriding the computed one",
+							Optional:            true,
+							Validators: []validator.String{
+								clickhouseTypeValidator{},
+							},
+						},
+						"kafkaengine_expression": schema.StringAttribute{
+							MarkdownDescription: "Expression converting the kafka engine column overriding the computed one, e.g. ``toUInt64(`id`)``, it is aliased with the column name, not supported on split ranges",
+							Optional:            true,
+						},
+						"athena_type": schema.StringAttribute{
+							MarkdownDescription: "Athena type overriding the computed one",
+							Optional:            true,
+						},
+						"comment": schema.StringAttribute{
+							MarkdownDescription: "PostgreSQL column comment, rendered as the Clickhouse column comment in `clickhouse_create_table`",
+							Optional:            true,
+						},
+						"fields": schema.ListNestedAttribute{
+							MarkdownDescription: "Fields of the PostgreSQL composite type, in their declaration order",
+							Optional:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										MarkdownDescription: "PostgreSQL field name",
+										Required:            true,
+									},
+									"type": schema.StringAttribute{
+										MarkdownDescription: "PostgreSQL field type",
+										Required:            true,
+									},
+									"numeric_precision": schema.Int64Attribute{
+										MarkdownDescription: "PostgreSQL numeric precision when apply",
+										Optional:            true,
+									},
+									"numeric_scale": schema.Int64Attribute{
+										MarkdownDescription: "PostgreSQL numeric scale when apply",
+										Optional:            true,
+									},
+									"character_maximum_length": schema.Int64Attribute{
+										MarkdownDescription: "PostgreSQL character length when apply",
+										Optional:            true,
+									},
+									"datetime_precision": schema.Int64Attribute{
+										MarkdownDescription: "Precison for timestamp",
+										Optional:            true,
+									},
+								},
+							},
+						},
+						"enum_values": schema.ListAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "Labels of the PostgreSQL enum type, in their declaration order",
+							Optional:            true,
+						},
+						"enum_strategy": schema.StringAttribute{
+							MarkdownDescription: "Clickhouse type for enum column: `Enum` (default) for `Enum8`/`Enum16` or `LowCardinality` for `LowCardinality(String)`",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(EnumStrategyEnum, EnumStrategyLowCardinality),
+							},
+						},
+					},
+				},
+			},
+			"json_strategy": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse type for json/jsonb columns: `String` (default), `JSON` or `Map` for `Map(String, String)`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(JSONStrategyString, JSONStrategyJSON, JSONStrategyMap),
+				},
+			},
+			"time_strategy": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse type for time columns: `Int64` (default) for microseconds since midnight or `Time` for `Time64(p)`, Debezium `time.precision.mode` being `adaptive`, times up to a precision of 3 being sent in milliseconds",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(TimeStrategyInt64, TimeStrategyTime),
+				},
+			},
+			"interval_strategy": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse type for interval columns: `Int64` (default) for seconds, Debezium `interval.handling.mode` being `numeric`, or `String` for ISO 8601 durations, Debezium `interval.handling.mode` being `string`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(IntervalStrategyInt64, IntervalStrategyString),
+				},
+			},
+			"on_unsupported_type": schema.StringAttribute{
+				MarkdownDescription: "Behaviour on columns with an unsupported type: `error` (default), `warn` to convert them to String with a warning or `skip` to leave them out with a warning",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(OnUnsupportedTypeError, OnUnsupportedTypeWarn, OnUnsupportedTypeSkip),
+				},
+			},
+			"binary_handling_mode": schema.StringAttribute{
+				MarkdownDescription: "Debezium `binary.handling.mode` used for bytea columns: `bytes` (default), `base64` or `hex`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(BinaryHandlingModeBytes, BinaryHandlingModeBase64, BinaryHandlingModeHex),
+				},
+			},
+			"cdc_strategy": schema.StringAttribute{
+				MarkdownDescription: "CDC landing table strategy: `None` (default) or `ReplacingMergeTree` to append the `_version` and `_is_deleted` columns computed from the Debezium `__op` and `__lsn` or `__ts_ms` fields, the table engine defaulting to `ReplacingMergeTree(_version, _is_deleted)`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(CDCStrategyNone, CDCStrategyReplacingMergeTree),
+				},
+			},
+			"cdc_version": schema.StringAttribute{
+				MarkdownDescription: "Debezium field giving the `_version` of the `ReplacingMergeTree` strategy: `lsn` (default) for `__lsn` or `ts_ms` for `__ts_ms`, Debezium `ExtractNewRecordState` being expected to add them with `add.fields`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(CDCVersionLSN, CDCVersionTsMs),
+				},
+			},
+			"table_name": schema.StringAttribute{
+				MarkdownDescription: "PostgreSQL table name, used to guess the primary key and to name the Clickhouse table",
+				Optional:            true,
+			},
+			"schema_name": schema.StringAttribute{
+				MarkdownDescription: "PostgreSQL schema name of the table, `public` by default",
+				Optional:            true,
+			},
+			"unique_constraints": schema.ListNestedAttribute{
+				MarkdownDescription: "PostgreSQL unique constraints of the table, without primary key the first one on non-nullable columns is used as guessed primary key",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "PostgreSQL constraint name",
+							Optional:            true,
+						},
+						"columns": schema.ListAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "PostgreSQL columns of the constraint, in the constraint order",
+							Required:            true,
+						},
+					},
+				},
+			},
+			"clickhouse_table_name": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse table name, `table_name` prefixed by `schema_name` and `_` unless the schema is `public`",
+				Computed:            true,
+			},
+			"clickhouse_table": schema.SingleNestedAttribute{
+				MarkdownDescription: "Options of the Clickhouse table rendered in `clickhouse_create_table`",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"database": schema.StringAttribute{
+						MarkdownDescription: "Clickhouse database of the table, the current database when unset",
+						Optional:            true,
+					},
+					"engine": schema.StringAttribute{
+						MarkdownDescription: "Clickhouse table engine, e.g. `ReplacingMergeTree(updated_at)`, `MergeTree` by default",
+						Optional:            true,
+					},
+					"order_by": schema.ListAttribute{
+						ElementType:         types.StringType,
+						MarkdownDescription: "Expressions of the sorting key, the primary key has to be a prefix of them and is rendered in a PRIMARY KEY clause when shorter. Default to the primary key, or the guessed primary key, `tuple()` without any",
+						Optional:            true,
+					},
+					"partition_by": schema.StringAttribute{
+						MarkdownDescription: "Partition key expression, e.g. ``toYYYYMM(`created_at`)``",
+						Optional:            true,
+					},
+					"settings": schema.MapAttribute{
+						ElementType:         types.StringType,
+						MarkdownDescription: "Table settings, e.g. `{ index_granularity = 8192 }`, numbers are rendered as is and other values quoted",
+						Optional:            true,
+					},
+					"comment": schema.StringAttribute{
+						MarkdownDescription: "Clickhouse table comment",
+						Optional:            true,
+					},
+				},
+			},
+			"clickhouse_create_table": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse CREATE TABLE statement of the converted columns and the domain constraints, null without `table_name`",
+				Computed:            true,
+			},
+			"cluster": schema.SingleNestedAttribute{
+				MarkdownDescription: "Cluster of the tables, the statements are rendered with ON CLUSTER, `clickhouse_create_table` as a replicated local table and `clickhouse_create_distributed_table` as the Distributed table over it, named after `clickhouse_table_name`",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						MarkdownDescription: "Clickhouse cluster name",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"zookeeper_path": schema.StringAttribute{
+						MarkdownDescription: "ZooKeeper path of the replicated local table, `/clickhouse/tables/{shard}/{database}/{table}` by default",
+						Optional:            true,
+					},
+					"replica_name": schema.StringAttribute{
+						MarkdownDescription: "Replica name of the replicated local table, `{replica}` by default",
+						Optional:            true,
+					},
+					"local_table_suffix": schema.StringAttribute{
+						MarkdownDescription: "Suffix of the local table name, `_local` by default",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"sharding_key": schema.StringAttribute{
+						MarkdownDescription: "Sharding key expression of the Distributed table, default to `cityHash64` of the primary key, or the guessed primary key, `rand()` without any",
+						Optional:            true,
+					},
+				},
+			},
+			"clickhouse_create_distributed_table": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse CREATE TABLE statement of the Distributed table over the local tables, null without `cluster` or without `table_name`",
+				Computed:            true,
+			},
+			"kafka_engine": schema.SingleNestedAttribute{
+				MarkdownDescription: "Settings of the kafka engine table rendered in `clickhouse_kafkaengine_create_table`",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"table_name": schema.StringAttribute{
+						MarkdownDescription: "Kafka engine table name, `clickhouse_table_name` suffixed by `_kafka` by default",
+						Optional:            true,
+					},
+					"broker_list": schema.ListAttribute{
+						ElementType:         types.StringType,
+						MarkdownDescription: "Kafka brokers, e.g. `kafka-1:9092`, rendered in `kafka_broker_list`",
+						Required:            true,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+						},
+					},
+					"topic_list": schema.ListAttribute{
+						ElementType:         types.StringType,
+						MarkdownDescription: "Kafka topics, rendered in `kafka_topic_list`",
+						Required:            true,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+						},
+					},
+					"group_name": schema.StringAttribute{
+						MarkdownDescription: "Kafka consumer group, rendered in `kafka_group_name`",
+						Required:            true,
+					},
+					"format": schema.StringAttribute{
+						MarkdownDescription: "Format of the messages, rendered in `kafka_format`, `AvroConfluent` by default",
+						Optional:            true,
+					},
+					"schema_registry_url": schema.StringAttribute{
+						MarkdownDescription: "Confluent schema registry URL of the `AvroConfluent` format, rendered in `format_avro_schema_registry_url`",
+						Optional:            true,
+					},
+					"num_consumers": schema.Int64Attribute{
+						MarkdownDescription: "Number of consumers of the table, rendered in `kafka_num_consumers`",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"handle_error_mode": schema.StringAttribute{
+						MarkdownDescription: "Handling of the messages failing to parse, rendered in `kafka_handle_error_mode`: `default` to throw or `stream` to fill the `_error` and `_raw_message` virtual columns",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(KafkaEngineHandleErrorModeDefault, KafkaEngineHandleErrorModeStream),
+						},
+					},
+					"skip_broken_messages": schema.Int64Attribute{
+						MarkdownDescription: "Number of messages failing to parse tolerated per block, rendered in `kafka_skip_broken_messages`",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+				},
+			},
+			"clickhouse_kafkaengine_create_table": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse CREATE TABLE statement of the kafka engine table reading `clickhouse_kafkaengine_columns`, in the `clickhouse_table` database, null without `kafka_engine` or without table name",
+				Computed:            true,
+			},
+			"materialized_view": schema.SingleNestedAttribute{
+				MarkdownDescription: "Options of the materialized view moving the kafka engine table rows to the Clickhouse table, rendered in `clickhouse_create_materialized_view`",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						MarkdownDescription: "Materialized view name, `clickhouse_table_name` suffixed by `_mv` by default",
+						Optional:            true,
+					},
+					"where": schema.ListAttribute{
+						ElementType:         types.StringType,
+						MarkdownDescription: "Conditions of the kafka engine rows inserted in the table, joined with AND, e.g. ``isNotNull(`id`)``",
+						Optional:            true,
+					},
+					"virtual_columns": schema.MapAttribute{
+						ElementType:         types.StringType,
+						MarkdownDescription: "Kafka engine virtual columns (`_topic`, `_key`, `_partition`, `_offset`, `_timestamp` or `_timestamp_ms`) mapped to the audit column appended to the Clickhouse columns, e.g. `{ _offset = \"_kafka_offset\" }`",
+						Optional:            true,
+						Validators: []validator.Map{
+							mapvalidator.KeysAre(stringvalidator.OneOf(kafkaEngineVirtualColumnNames()...)),
+						},
+					},
+				},
+			},
+			"clickhouse_create_materialized_view": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse CREATE MATERIALIZED VIEW statement selecting `clickhouse_kafkaengine_columns_mapping` from the kafka engine table to the Clickhouse table, null without `materialized_view`, `kafka_engine` or table name",
+				Computed:            true,
+			},
+			"primary_key_guessing": schema.SingleNestedAttribute{
+				MarkdownDescription: "Primary key guessing from the column names when the table has no primary key, the guessed column is made non-nullable",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						MarkdownDescription: "Guess the primary key, `true` by default",
+						Optional:            true,
+					},
+					"patterns": schema.ListAttribute{
+						ElementType:         types.StringType,
+						MarkdownDescription: "Regular expressions matching the column names tried in order, `{table}` being replaced by `table_name`, patterns with `{table}` are skipped without `table_name`. Default to `^{table}_id$`, `^id$` and `_id$`",
+						Optional:            true,
+					},
+					"prefer_non_nullable": schema.BoolAttribute{
+						MarkdownDescription: "Among the columns matching a pattern, prefer non-nullable integer or uuid columns, then non-nullable columns, over the first one, `true` by default",
+						Optional:            true,
 					},
 				},
 			},
@@ -156,6 +598,11 @@ func (d *Psql2ChDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 					},
 				},
 			},
+			"clickhouse_constraints": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Clickhouse CONSTRAINT clauses rendered from the PostgreSQL domain checks",
+				Computed:            true,
+			},
 			"clickhouse_kafkaengine_columns_mapping": schema.ListAttribute{
 				MarkdownDescription: "Mapping between kafka engine with avroconfluent format to clickhouse base types",
 				Computed:            true,
@@ -186,6 +633,19 @@ func (d *Psql2ChDataSource) Configure(ctx context.Context, req datasource.Config
 	if req.ProviderData == nil {
 		return
 	}
+
+	providerData, ok := req.ProviderData.(*DataToolsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *DataToolsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
 }
 
 func (d *Psql2ChDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -198,66 +658,338 @@ func (d *Psql2ChDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
+	defaultInetType := InetTypeIPv6
+	if d.providerData != nil && d.providerData.InetType != "" {
+		defaultInetType = d.providerData.InetType
+	}
+	defaultTimezone := "UTC"
+	if d.providerData != nil && d.providerData.Timezone != "" {
+		defaultTimezone = d.providerData.Timezone
+	}
+
+	hasPrimaryKey := false
+	var candidates []psqlColumnDefinition
+	for _, column := range data.PostgresColumns {
+		hasPrimaryKey = hasPrimaryKey || column.IsPrimaryKey.ValueBool()
+		candidates = append(candidates, psqlColumnDefinition{
+			Name:       column.Name.ValueString(),
+			Type:       column.typeName().Type,
+			IsNullable: column.IsNullable.ValueBool(),
+		})
+	}
+	for i, constraint := range data.UniqueConstraints {
+		for j, constraintColumn := range constraint.Columns {
+			found := false
+			for _, candidate := range candidates {
+				found = found || candidate.Name == constraintColumn.ValueString()
+			}
+			if !found {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("unique_constraints").AtListIndex(i).AtName("columns").AtListIndex(j),
+					"Unknown Constraint Column",
+					fmt.Sprintf("Column %s of the unique constraint isn't in postgres_columns", constraintColumn.ValueString()),
+				)
+			}
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var guessedPrimaryKeyColumns []string
+	if data.PrimaryKeyGuessing == nil || data.PrimaryKeyGuessing.Enabled.IsNull() || data.PrimaryKeyGuessing.Enabled.ValueBool() {
+		patterns := defaultPrimaryKeyGuessingPatterns
+		preferNonNullable := true
+		if data.PrimaryKeyGuessing != nil {
+			if data.PrimaryKeyGuessing.Patterns != nil {
+				patterns = nil
+				for _, pattern := range data.PrimaryKeyGuessing.Patterns {
+					patterns = append(patterns, pattern.ValueString())
+				}
+			}
+			if !data.PrimaryKeyGuessing.PreferNonNullable.IsNull() {
+				preferNonNullable = data.PrimaryKeyGuessing.PreferNonNullable.ValueBool()
+			}
+		}
+		err, i, compiledPatterns := compilePrimaryKeyGuessingPatterns(patterns, data.TableName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("primary_key_guessing").AtName("patterns").AtListIndex(i),
+				"Invalid Primary Key Guessing Pattern",
+				fmt.Sprintf("Unable to compile the pattern %s: %s", patterns[i], err.Error()),
+			)
+			return
+		}
+		// Nothing is guessed when the table has a primary key, a unique constraint on
+		// non-nullable columns being a better guess than the column names
+		if !hasPrimaryKey {
+			if constraint := uniqueNonNullConstraint(data.UniqueConstraints, candidates); constraint >= 0 {
+				for _, column := range data.UniqueConstraints[constraint].Columns {
+					guessedPrimaryKeyColumns = append(guessedPrimaryKeyColumns, column.ValueString())
+				}
+			} else if guess := guessPrimaryKey(candidates, compiledPatterns, preferNonNullable); guess >= 0 {
+				guessedPrimaryKeyColumns = []string{candidates[guess].Name}
+			}
+		}
+	}
+
+	var identity psql2chIdentity
 	var columnNames []string
+	var tableColumns []clickhouseColumnDefinition
 	var clickhouseColumns []ClickhouseColumn
 	var clickhouseKafkaEngineColumns []ClickhouseColumn
 	var clickhouseKafkaEngineColumnsMapping []attr.Value
+	var athenaColumns []AthenaColumn
+	var constraints []types.String
 	var primaryKey []types.String
-	var guessedPrimaryKey *types.String
-	for _, column := range data.PostgresColumns {
-		isGuessedPrimaryKey := false
+	for i, column := range data.PostgresColumns {
 		columnName := column.Name
+		isGuessedPrimaryKey := false
+		for _, guessedColumn := range guessedPrimaryKeyColumns {
+			isGuessedPrimaryKey = isGuessedPrimaryKey || guessedColumn == columnName.ValueString()
+		}
+		jsonStrategy := data.JSONStrategy.ValueString()
+		if !column.JSONStrategy.IsNull() {
+			jsonStrategy = column.JSONStrategy.ValueString()
+		}
+		inetType := defaultInetType
+		if !column.InetType.IsNull() {
+			inetType = column.InetType.ValueString()
+		}
+		timezone := defaultTimezone
+		if !column.Timezone.IsNull() {
+			timezone = column.Timezone.ValueString()
+		}
+		var enumValues []string
+		for _, enumValue := range column.EnumValues {
+			enumValues = append(enumValues, enumValue.ValueString())
+		}
+		typeName := column.typeName()
+		definition := psqlColumnDefinition{
+			Name:                      columnName.ValueString(),
+			Type:                      typeName.Type,
+			NumericPrecision:          int64OrDefault(column.NumericPrecision, typeName.NumericPrecision),
+			NumericScale:              int64OrDefault(column.NumericScale, typeName.NumericScale),
+			CharacterMaximumLength:    int64OrDefault(column.CharacterMaximumLength, typeName.CharacterMaximumLength),
+			DatetimePrecicion:         int64OrDefault(column.DatetimePrecicion, typeName.DatetimePrecision),
+			HasNumericPrecision:       !column.NumericPrecision.IsNull() || !typeName.NumericPrecision.IsNull(),
+			HasNumericScale:           !column.NumericScale.IsNull() || !typeName.NumericScale.IsNull(),
+			HasCharacterMaximumLength: !column.CharacterMaximumLength.IsNull() || !typeName.CharacterMaximumLength.IsNull(),
+			IsNullable:                column.IsNullable.ValueBool(),
+			IsPrimaryKey:              column.IsPrimaryKey.ValueBool(),
+			IsGuessedPrimaryKey:       isGuessedPrimaryKey,
+			JSONStrategy:              jsonStrategy,
+			JSONStruct:                column.JSONStruct.ValueString(),
+			InetType:                  inetType,
+			EnumValues:                enumValues,
+			EnumStrategy:              column.EnumStrategy.ValueString(),
+			TimeStrategy:              data.TimeStrategy.ValueString(),
+			IntervalStrategy:          data.IntervalStrategy.ValueString(),
+			Timezone:                  timezone,
+			BinaryHandlingMode:        data.BinaryHandlingMode.ValueString(),
+			GeometryType:              column.GeometryType.ValueString(),
+			GeometryFormat:            column.GeometryFormat.ValueString(),
+			RangeStrategy:             column.RangeStrategy.ValueString(),
+		}
+		for _, field := range column.Fields {
+			definition.Fields = append(definition.Fields, definition.fieldDefinition(field))
+		}
+		if d.providerData != nil {
+			if rule, ok := findTypeMappingRule(d.providerData.TypeMappingRules, definition); ok {
+				column = rule.apply(column, definition)
+			}
+		}
+		err, conversion := convertPostgreSqlColumn(definition)
+		if definition.isSplitRange() && !column.KafkaEngineExpression.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("postgres_columns").AtListIndex(i).AtName("kafkaengine_expression"),
+				"Invalid Attribute Combination",
+				fmt.Sprintf("Column %s is a split range, its kafka engine expression can't be overridden", definition.Name),
+			)
+			continue
+		}
+		err, conversion = overrideColumnConversion(column, conversion, err)
+		if err != nil {
+			typePath := path.Root("postgres_columns").AtListIndex(i).AtName("type")
+			unmappedDetail := func(err error) string {
+				return fmt.Sprintf("Column %s of type %s can't be mapped: %s. ", definition.Name, column.Type.ValueString(), err.Error()) +
+					"Set clickhouse_type, with athena_type when it has no Athena equivalent, or base_type to a supported PostgreSQL type, or on_unsupported_type to warn or skip."
+			}
+			switch data.OnUnsupportedType.ValueString() {
+			case OnUnsupportedTypeWarn:
+				// The overrides still apply to the String column and may fail on their own
+				_, stringConversion := convertPostgreSqlColumn(definition.stringDefinition())
+				overrideErr, stringConversion := overrideColumnConversion(column, stringConversion, nil)
+				if overrideErr != nil {
+					resp.Diagnostics.AddAttributeError(typePath, "Unable to map PostgreSQL type", unmappedDetail(overrideErr))
+					continue
+				}
+				resp.Diagnostics.AddAttributeWarning(
+					typePath,
+					"Unsupported PostgreSQL type",
+					fmt.Sprintf("Column %s is converted to String: %s", definition.Name, err.Error()),
+				)
+				definition = definition.stringDefinition()
+				conversion = stringConversion
+			case OnUnsupportedTypeSkip:
+				resp.Diagnostics.AddAttributeWarning(
+					typePath,
+					"Unsupported PostgreSQL type",
+					fmt.Sprintf("Column %s is skipped: %s", definition.Name, err.Error()),
+				)
+				continue
+			default:
+				// Keep going to report every unsupported column at once
+				resp.Diagnostics.AddAttributeError(typePath, "Unable to map PostgreSQL type", unmappedDetail(err))
+				continue
+			}
+		}
 		columnNames = append(columnNames, columnName.ValueString())
 		if column.IsPrimaryKey.ValueBool() {
 			primaryKey = append(primaryKey, columnName)
 		}
-		if strings.HasSuffix(columnName.ValueString(), "_id") && guessedPrimaryKey == nil {
-			guessedPrimaryKey = &columnName
-			isGuessedPrimaryKey = true
-		}
-		err, clickhouseType := postgreSqlToClickhouseType(
-			column.Type.ValueString(),
-			column.NumericPrecision.ValueInt64(),
-			column.NumericScale.ValueInt64(),
-			column.DatetimePrecicion.ValueInt64(),
-			column.IsNullable.ValueBool(),
-			column.IsPrimaryKey.ValueBool(),
-			isGuessedPrimaryKey,
-		)
+		if isGuessedPrimaryKey {
+			if column.IsNullable.ValueBool() && !column.IsPrimaryKey.ValueBool() {
+				resp.Diagnostics.AddAttributeWarning(
+					path.Root("postgres_columns").AtListIndex(i).AtName("is_nullable"),
+					"Nullable Column Guessed as Primary Key",
+					fmt.Sprintf("Column %s is guessed as primary key, it is made non-nullable", definition.Name),
+				)
+			}
+		}
+		for i, check := range column.DomainChecks {
+			constraintName := fmt.Sprintf("%s_%s_%d", definition.Name, parsePostgreSqlType(column.Type.ValueString()).Name, i+1)
+			constraints = append(constraints, types.StringValue(clickhouseDomainConstraint(definition, constraintName, check.ValueString())))
+		}
+		var domainChecks []string
+		for _, check := range column.DomainChecks {
+			domainChecks = append(domainChecks, check.ValueString())
+		}
+		identity.Columns = append(identity.Columns, psqlColumnIdentity{
+			Definition:            definition,
+			DomainChecks:          domainChecks,
+			ClickhouseType:        column.ClickhouseType.ValueString(),
+			KafkaEngineType:       column.KafkaEngineType.ValueString(),
+			KafkaEngineExpression: column.KafkaEngineExpression.ValueString(),
+			AthenaType:            column.AthenaType.ValueString(),
+			Comment:               column.Comment.ValueString(),
+		})
+		for _, clickhouseColumn := range conversion.ClickhouseColumns {
+			tableColumns = append(tableColumns, clickhouseColumnDefinition{
+				Name:    clickhouseColumn.Name.ValueString(),
+				Type:    clickhouseColumn.Type.ValueString(),
+				Comment: column.Comment.ValueString(),
+			})
+		}
+		clickhouseColumns = append(clickhouseColumns, conversion.ClickhouseColumns...)
+		clickhouseKafkaEngineColumns = append(clickhouseKafkaEngineColumns, conversion.KafkaEngineColumn)
+		clickhouseKafkaEngineColumnsMapping = append(clickhouseKafkaEngineColumnsMapping, conversion.KafkaEngineColumnsMapping...)
+		athenaColumns = append(athenaColumns, conversion.AthenaColumns...)
+	}
+	if data.CDCStrategy.ValueString() == CDCStrategyReplacingMergeTree {
+		err, conversion, kafkaEngineColumns := cdcConversion(data.CDCVersion.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError(
-				"Unable to map PostgreSQL type",
-				"An unexpected error occurred when mapping type: "+err.Error(),
+				"Unable to add the CDC columns",
+				"An unexpected error occurred when converting the CDC columns: "+err.Error(),
 			)
 			return
 		}
-		clickhouseColumns = append(clickhouseColumns, ClickhouseColumn{
-			Name: columnName,
-			Type: types.StringValue(clickhouseType),
-		})
-		clickhouseKafkaEngineColumns = append(clickhouseKafkaEngineColumns, ClickhouseColumn{
-			Name: columnName,
-			Type: types.StringValue(postgreSqlToKafkaEngineClickhouseType(
-				column.Type.ValueString(),
-				column.DatetimePrecicion.ValueInt64(),
-				column.IsNullable.ValueBool(),
-				column.IsPrimaryKey.ValueBool(),
-				isGuessedPrimaryKey,
-			)),
-		})
-		clickhouseKafkaEngineColumnsMapping = append(clickhouseKafkaEngineColumnsMapping, mappingKafkaEngineTypes(columnName.ValueString(), column.Type.ValueString()))
+		for _, column := range conversion.ClickhouseColumns {
+			for _, columnName := range columnNames {
+				if columnName == column.Name.ValueString() {
+					resp.Diagnostics.AddAttributeError(
+						path.Root("cdc_strategy"),
+						"Conflicting CDC Column",
+						fmt.Sprintf("Column %s is appended by the %s strategy, it can't be a PostgreSQL column", columnName, CDCStrategyReplacingMergeTree),
+					)
+				}
+			}
+			tableColumns = append(tableColumns, clickhouseColumnDefinition{
+				Name: column.Name.ValueString(),
+				Type: column.Type.ValueString(),
+			})
+		}
+		if len(primaryKey) == 0 && len(guessedPrimaryKeyColumns) == 0 {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("cdc_strategy"),
+				"ReplacingMergeTree Without Primary Key",
+				"The table has neither primary key nor guessed primary key, its rows can't be deduplicated",
+			)
+		}
+		clickhouseColumns = append(clickhouseColumns, conversion.ClickhouseColumns...)
+		clickhouseKafkaEngineColumns = append(clickhouseKafkaEngineColumns, kafkaEngineColumns...)
+		clickhouseKafkaEngineColumnsMapping = append(clickhouseKafkaEngineColumnsMapping, conversion.KafkaEngineColumnsMapping...)
+		athenaColumns = append(athenaColumns, conversion.AthenaColumns...)
 	}
-	var athenaColumns []AthenaColumn
-	for _, column := range clickhouseColumns {
-		athenaColumns = append(athenaColumns, AthenaColumn{
-			Name: types.StringValue(column.Name.ValueString()),
-			Type: types.StringValue(clickhouseToAthena(column.Type.ValueString())),
-		})
+	materializedView := data.MaterializedView.options()
+	if materializedView != nil {
+		err, conversion := materializedView.auditConversion()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to add the audit columns",
+				"An unexpected error occurred when converting the audit columns: "+err.Error(),
+			)
+			return
+		}
+		for _, column := range conversion.ClickhouseColumns {
+			for _, tableColumn := range tableColumns {
+				if tableColumn.Name == column.Name.ValueString() {
+					resp.Diagnostics.AddAttributeError(
+						path.Root("materialized_view").AtName("virtual_columns"),
+						"Conflicting Audit Column",
+						fmt.Sprintf("Column %s is mapped from a kafka engine virtual column, it can't be a converted column", tableColumn.Name),
+					)
+				}
+			}
+			tableColumns = append(tableColumns, clickhouseColumnDefinition{
+				Name: column.Name.ValueString(),
+				Type: column.Type.ValueString(),
+			})
+		}
+		clickhouseColumns = append(clickhouseColumns, conversion.ClickhouseColumns...)
+		clickhouseKafkaEngineColumnsMapping = append(clickhouseKafkaEngineColumnsMapping, conversion.KafkaEngineColumnsMapping...)
+		athenaColumns = append(athenaColumns, conversion.AthenaColumns...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	data.Id = types.StringValue(strings.Join(columnNames, "_"))
+	identity.CDCStrategy = data.CDCStrategy.ValueString()
+	identity.CDCVersion = data.CDCVersion.ValueString()
+	identity.ClickhouseTable = data.ClickhouseTable.options(cdcEngine(data.CDCStrategy.ValueString()))
+	identity.KafkaEngine = data.KafkaEngine.options()
+	identity.MaterializedView = materializedView
+	identity.Cluster = data.Cluster.options()
+	data.ClickhouseTableName = types.StringNull()
+	if !data.TableName.IsNull() {
+		schemaName := data.SchemaName.ValueString()
+		if schemaName == "" {
+			schemaName = "public"
+		}
+		identity.SchemaName = schemaName
+		identity.TableName = data.TableName.ValueString()
+		data.ClickhouseTableName = data.TableName
+		if schemaName != "public" {
+			data.ClickhouseTableName = types.StringValue(schemaName + "_" + data.TableName.ValueString())
+		}
+	}
+	err, id := identity.hash()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to compute the identifier",
+			"An unexpected error occurred when hashing the data source input: "+err.Error(),
+		)
+		return
+	}
+	data.Id = types.StringValue(id)
 	data.ClickhousePrimaryKey = primaryKey
-	if guessedPrimaryKey != nil {
-		data.ClickhouseGuessedPrimaryKey = []types.String{*guessedPrimaryKey}
+	// Columns left out as unsupported aren't part of the guessed primary key
+	for _, guessedColumn := range guessedPrimaryKeyColumns {
+		for _, columnName := range columnNames {
+			if guessedColumn == columnName {
+				data.ClickhouseGuessedPrimaryKey = append(data.ClickhouseGuessedPrimaryKey, types.StringValue(columnName))
+			}
+		}
 	}
 	data.ClickhouseColumns = clickhouseColumns
 	data.ClickhouseKafkaEngineColumns = clickhouseKafkaEngineColumns
@@ -267,6 +999,99 @@ func (d *Psql2ChDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 	data.ClickhouseKafkaEngineColumnsMapping = clickhouseKafkaEngineColumnsMappingValues
 	data.AthenaColumns = athenaColumns
+	data.ClickhouseConstraints = constraints
+	data.ClickhouseCreateTable = types.StringNull()
+	data.ClickhouseCreateDistributedTable = types.StringNull()
+	if !data.ClickhouseTableName.IsNull() {
+		tablePrimaryKey := data.ClickhousePrimaryKey
+		if len(tablePrimaryKey) == 0 {
+			tablePrimaryKey = data.ClickhouseGuessedPrimaryKey
+		}
+		table := clickhouseCreateTable{
+			clickhouseTableOptions: identity.ClickhouseTable,
+			Name:                   data.ClickhouseTableName.ValueString(),
+			Columns:                tableColumns,
+		}
+		for _, column := range tablePrimaryKey {
+			table.PrimaryKey = append(table.PrimaryKey, column.ValueString())
+		}
+		for _, constraint := range constraints {
+			table.Constraints = append(table.Constraints, constraint.ValueString())
+		}
+		// The rows are written to the Distributed table named after the table, sharding them to the local tables
+		if identity.Cluster != nil {
+			table.Cluster = identity.Cluster.Name
+			table.Name += identity.Cluster.LocalTableSuffix
+			table.Engine = identity.Cluster.replicatedEngine(table.Engine)
+			distributedTable := distributedCreateTable{
+				Cluster:     identity.Cluster.Name,
+				Database:    table.Database,
+				Name:        data.ClickhouseTableName.ValueString(),
+				LocalTable:  table.Name,
+				ShardingKey: identity.Cluster.shardingKey(table.PrimaryKey),
+			}
+			data.ClickhouseCreateDistributedTable = types.StringValue(distributedTable.render())
+		}
+		err, createTable := table.render()
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("clickhouse_table").AtName("order_by"),
+				"Invalid Sorting Key",
+				err.Error(),
+			)
+			return
+		}
+		data.ClickhouseCreateTable = types.StringValue(createTable)
+	}
+	data.ClickhouseKafkaEngineCreateTable = types.StringNull()
+	data.ClickhouseCreateMaterializedView = types.StringNull()
+	if identity.KafkaEngine != nil {
+		kafkaEngineTable := kafkaEngineCreateTable{
+			kafkaEngineOptions: *identity.KafkaEngine,
+			Database:           identity.ClickhouseTable.Database,
+		}
+		if identity.Cluster != nil {
+			kafkaEngineTable.Cluster = identity.Cluster.Name
+		}
+		if kafkaEngineTable.TableName == "" && !data.ClickhouseTableName.IsNull() {
+			kafkaEngineTable.TableName = data.ClickhouseTableName.ValueString() + KafkaEngineTableSuffix
+		}
+		for _, column := range clickhouseKafkaEngineColumns {
+			kafkaEngineTable.Columns = append(kafkaEngineTable.Columns, clickhouseColumnDefinition{
+				Name: column.Name.ValueString(),
+				Type: column.Type.ValueString(),
+			})
+		}
+		if kafkaEngineTable.TableName != "" {
+			data.ClickhouseKafkaEngineCreateTable = types.StringValue(kafkaEngineTable.render())
+		}
+		if materializedView != nil && kafkaEngineTable.TableName != "" && !data.ClickhouseTableName.IsNull() {
+			view := materializedViewCreate{
+				materializedViewOptions: *materializedView,
+				Database:                identity.ClickhouseTable.Database,
+				TableName:               data.ClickhouseTableName.ValueString(),
+				KafkaEngineTable:        kafkaEngineTable.TableName,
+			}
+			if identity.Cluster != nil {
+				view.Cluster = identity.Cluster.Name
+			}
+			if view.Name == "" {
+				view.Name = data.ClickhouseTableName.ValueString() + MaterializedViewSuffix
+			}
+			for _, value := range clickhouseKafkaEngineColumnsMapping {
+				expression, ok := value.(types.String)
+				if !ok {
+					resp.Diagnostics.AddError(
+						"Unexpected Kafka Engine Mapping Type",
+						fmt.Sprintf("Expected types.String, got: %T. Please report this issue to the provider developers.", value),
+					)
+					return
+				}
+				view.Mapping = append(view.Mapping, expression.ValueString())
+			}
+			data.ClickhouseCreateMaterializedView = types.StringValue(view.render())
+		}
+	}
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
 	tflog.Trace(ctx, "read a data source")
@@ -275,6 +1100,166 @@ func (d *Psql2ChDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// psqlColumnConversion holds the outputs of a PostgreSQL column conversion,
+// split ranges give two Clickhouse and Athena columns.
+type psqlColumnConversion struct {
+	ClickhouseColumns         []ClickhouseColumn
+	KafkaEngineColumn         ClickhouseColumn
+	KafkaEngineColumnsMapping []attr.Value
+	AthenaColumns             []AthenaColumn
+}
+
+func convertPostgreSqlColumn(column psqlColumnDefinition) (error, psqlColumnConversion) {
+	var conversion psqlColumnConversion
+	err, clickhouseType := postgreSqlToClickhouseType(column)
+	if err != nil {
+		return err, conversion
+	}
+	err, kafkaEngineType := postgreSqlToKafkaEngineClickhouseType(column)
+	if err != nil {
+		return err, conversion
+	}
+	conversion.KafkaEngineColumn = ClickhouseColumn{
+		Name: types.StringValue(column.Name),
+		Type: types.StringValue(kafkaEngineType),
+	}
+	// Split ranges are read from a single kafka engine column into two Clickhouse columns
+	if column.isSplitRange() {
+		for i, bound := range []string{"lower", "upper"} {
+			boundDefinition := column.rangeBoundDefinition(bound)
+			_, boundType := postgreSqlToClickhouseType(boundDefinition)
+			err, athenaType := postgreSqlToAthenaType(boundDefinition, boundType)
+			if err != nil {
+				return err, conversion
+			}
+			conversion.ClickhouseColumns = append(conversion.ClickhouseColumns, ClickhouseColumn{
+				Name: types.StringValue(boundDefinition.Name),
+				Type: types.StringValue(boundType),
+			})
+			boundExpression := rangeBoundExpression("`"+column.Name+"`", column, i+1)
+			conversion.KafkaEngineColumnsMapping = append(conversion.KafkaEngineColumnsMapping, types.StringValue(boundExpression+" as `"+boundDefinition.Name+"`"))
+			conversion.AthenaColumns = append(conversion.AthenaColumns, AthenaColumn{
+				Name: types.StringValue(boundDefinition.Name),
+				Type: types.StringValue(athenaType),
+			})
+		}
+		return err, conversion
+	}
+	err, athenaType := postgreSqlToAthenaType(column, clickhouseType)
+	if err != nil {
+		return err, conversion
+	}
+	conversion.ClickhouseColumns = []ClickhouseColumn{{
+		Name: types.StringValue(column.Name),
+		Type: types.StringValue(clickhouseType),
+	}}
+	conversion.KafkaEngineColumnsMapping = []attr.Value{mappingKafkaEngineTypes(column)}
+	conversion.AthenaColumns = []AthenaColumn{{
+		Name: types.StringValue(column.Name),
+		Type: types.StringValue(athenaType),
+	}}
+	return err, conversion
+}
+
+// overrideColumnConversion applies the column overrides to its conversion, the Athena type
+// following clickhouse_type unless athena_type is set. When the conversion failed on an
+// unsupported type, the column is converted from the overrides if clickhouse_type is set.
+func overrideColumnConversion(column PsqlColumn, conversion psqlColumnConversion, err error) (error, psqlColumnConversion) {
+	name := "`" + column.Name.ValueString() + "`"
+	if err != nil {
+		if column.ClickhouseType.IsNull() {
+			return err, conversion
+		}
+		conversion = psqlColumnConversion{
+			ClickhouseColumns:         []ClickhouseColumn{{Name: column.Name, Type: column.ClickhouseType}},
+			KafkaEngineColumn:         ClickhouseColumn{Name: column.Name, Type: column.ClickhouseType},
+			KafkaEngineColumnsMapping: []attr.Value{types.StringValue(name)},
+			AthenaColumns:             []AthenaColumn{{Name: column.Name, Type: column.AthenaType}},
+		}
+	}
+	if !column.ClickhouseType.IsNull() {
+		for i := range conversion.ClickhouseColumns {
+			conversion.ClickhouseColumns[i].Type = column.ClickhouseType
+		}
+		// The Athena columns follow the overridden type unless athena_type is set
+		if column.AthenaType.IsNull() {
+			err, athenaType := clickhouseToAthena(column.ClickhouseType.ValueString())
+			if err != nil {
+				return err, conversion
+			}
+			for i := range conversion.AthenaColumns {
+				conversion.AthenaColumns[i].Type = types.StringValue(athenaType)
+			}
+		}
+	}
+	if !column.KafkaEngineType.IsNull() {
+		conversion.KafkaEngineColumn.Type = column.KafkaEngineType
+	}
+	if !column.KafkaEngineExpression.IsNull() {
+		expression := column.KafkaEngineExpression.ValueString()
+		if expression != name {
+			expression += " as " + name
+		}
+		conversion.KafkaEngineColumnsMapping = []attr.Value{types.StringValue(expression)}
+	}
+	if !column.AthenaType.IsNull() {
+		for i := range conversion.AthenaColumns {
+			conversion.AthenaColumns[i].Type = column.AthenaType
+		}
+	}
+	return nil, conversion
+}
+
+// typeName returns the parsed column type, domains being converted as their base type.
+func (c PsqlColumn) typeName() PsqlTypeName {
+	if !c.BaseType.IsNull() {
+		return parsePostgreSqlType(c.BaseType.ValueString())
+	}
+	return parsePostgreSqlType(c.Type.ValueString())
+}
+
+// psql2chIdentity is the normalized input of the data source, the provider defaults, type mapping
+// rules and guessed primary key being applied to the columns.
+type psql2chIdentity struct {
+	SchemaName       string
+	TableName        string
+	CDCStrategy      string
+	CDCVersion       string
+	ClickhouseTable  clickhouseTableOptions
+	KafkaEngine      *kafkaEngineOptions
+	MaterializedView *materializedViewOptions
+	Cluster          *clickhouseClusterOptions
+	Columns          []psqlColumnIdentity
+}
+
+type psqlColumnIdentity struct {
+	Definition            psqlColumnDefinition
+	DomainChecks          []string
+	ClickhouseType        string
+	KafkaEngineType       string
+	KafkaEngineExpression string
+	AthenaType            string
+	Comment               string
+}
+
+// hash returns the hex encoded SHA-256 of the JSON encoded identity.
+func (i psql2chIdentity) hash() (error, string) {
+	content, err := json.Marshal(i)
+	if err != nil {
+		return err, ""
+	}
+	sum := sha256.Sum256(content)
+	return nil, hex.EncodeToString(sum[:])
+}
+
+// int64OrDefault returns the attribute value, or the default value when the attribute is absent.
+func int64OrDefault(attribute types.Int64, defaultValue types.Int64) int64 {
+	if attribute.IsNull() {
+		return defaultValue.ValueInt64()
+	}
+	return attribute.ValueInt64()
+}
+
 type NotImplementedType struct {
 	PSQLType string
 }
@@ -283,10 +1268,289 @@ func (e *NotImplementedType) Error() string {
 	return fmt.Sprintf("Type %s not implemented yet", e.PSQLType)
 }
 
-func postgreSqlToClickhouseType(psqlType string, numericPrecision int64, numericScale int64, datetimePrecicion int64, isNullable bool, isPrimaryKey bool, isGuessedPrimaryKey bool) (error, string) {
+type NotImplementedClickhouseType struct {
+	ClickhouseType string
+}
+
+func (e *NotImplementedClickhouseType) Error() string {
+	return fmt.Sprintf("Clickhouse type %s has no Athena equivalent yet", e.ClickhouseType)
+}
+
+// postgreSqlArrayElementType returns the element type of a PostgreSQL array type,
+// written either as an udt name (_int4) or with brackets (int4[], int4[3]).
+func postgreSqlArrayElementType(psqlType string) (string, bool) {
+	brackets := regexp.MustCompile(`\[\d*\]$`)
+	if brackets.MatchString(psqlType) {
+		return brackets.ReplaceAllString(psqlType, ""), true
+	}
+	if strings.HasPrefix(psqlType, "_") {
+		return strings.TrimPrefix(psqlType, "_"), true
+	}
+	return psqlType, false
+}
+
+// psqlColumnDefinition gathers a PostgreSQL column type and the attributes used to convert it.
+type psqlColumnDefinition struct {
+	Name                   string
+	Type                   string
+	NumericPrecision       int64
+	NumericScale           int64
+	CharacterMaximumLength int64
+	DatetimePrecicion      int64
+	// Has* tell whether the modifier is declared, an unconstrained numeric having no precision
+	HasNumericPrecision       bool
+	HasNumericScale           bool
+	HasCharacterMaximumLength bool
+	IsNullable                bool
+	IsPrimaryKey              bool
+	IsGuessedPrimaryKey       bool
+	JSONStrategy              string
+	JSONStruct                string
+	InetType                  string
+	EnumValues                []string
+	EnumStrategy              string
+	TimeStrategy              string
+	IntervalStrategy          string
+	Timezone                  string
+	BinaryHandlingMode        string
+	Fields                    []psqlColumnDefinition
+	GeometryType              string
+	GeometryFormat            string
+	RangeStrategy             string
+}
+
+// elementDefinition returns the definition of the elements when the column is an array,
+// elements are always nullable as PostgreSQL doesn't constrain them.
+func (c psqlColumnDefinition) elementDefinition() (psqlColumnDefinition, bool) {
+	elementType, ok := postgreSqlArrayElementType(c.Type)
+	element := c
+	element.Type = elementType
+	element.IsNullable = true
+	element.IsPrimaryKey = false
+	element.IsGuessedPrimaryKey = false
+	return element, ok
+}
+
+// fieldDefinition returns the definition of a composite type field, fields share the
+// column conversion settings and are always nullable.
+func (c psqlColumnDefinition) fieldDefinition(field PsqlField) psqlColumnDefinition {
+	typeName := parsePostgreSqlType(field.Type.ValueString())
+	return psqlColumnDefinition{
+		Name:                   field.Name.ValueString(),
+		Type:                   typeName.Type,
+		NumericPrecision:       int64OrDefault(field.NumericPrecision, typeName.NumericPrecision),
+		NumericScale:           int64OrDefault(field.NumericScale, typeName.NumericScale),
+		CharacterMaximumLength: int64OrDefault(field.CharacterMaximumLength, typeName.CharacterMaximumLength),
+		DatetimePrecicion:      int64OrDefault(field.DatetimePrecicion, typeName.DatetimePrecision),
+		IsNullable:             true,
+		JSONStrategy:           c.JSONStrategy,
+		InetType:               c.InetType,
+		TimeStrategy:           c.TimeStrategy,
+		IntervalStrategy:       c.IntervalStrategy,
+		Timezone:               c.Timezone,
+		BinaryHandlingMode:     c.BinaryHandlingMode,
+	}
+}
+
+// rangeSubtypeDefinition returns the definition of the range bounds when the column is a range,
+// or of the ranges when the column is a multirange.
+func (c psqlColumnDefinition) rangeSubtypeDefinition() (psqlColumnDefinition, bool) {
+	subtype, ok := postgreSqlRangeSubtypes[c.Type]
+	subtypeDefinition := c
+	subtypeDefinition.Type = subtype
+	subtypeDefinition.IsNullable = true
+	subtypeDefinition.IsPrimaryKey = false
+	subtypeDefinition.IsGuessedPrimaryKey = false
+	return subtypeDefinition, ok
+}
+
+// isSplitRange tells if the range column is converted to <name>_lower and <name>_upper columns.
+func (c psqlColumnDefinition) isSplitRange() bool {
+	return c.RangeStrategy == RangeStrategySplit && isRangeType(c.Type)
+}
+
+// rangeBoundDefinition returns the definition of the <name>_<bound> column of a split range.
+func (c psqlColumnDefinition) rangeBoundDefinition(bound string) psqlColumnDefinition {
+	boundDefinition, _ := c.rangeSubtypeDefinition()
+	boundDefinition.Name = c.Name + "_" + bound
+	return boundDefinition
+}
+
+// stringDefinition returns the definition of the column converted as a text column.
+func (c psqlColumnDefinition) stringDefinition() psqlColumnDefinition {
+	return psqlColumnDefinition{
+		Name:                c.Name,
+		Type:                "text",
+		IsNullable:          c.IsNullable,
+		IsPrimaryKey:        c.IsPrimaryKey,
+		IsGuessedPrimaryKey: c.IsGuessedPrimaryKey,
+	}
+}
+
+// isMilliTime tells if the time column is sent as a Debezium Time, counting milliseconds
+// since midnight, rather than a MicroTime, as the default adaptive time.precision.mode does
+// up to a precision of 3.
+func (c psqlColumnDefinition) isMilliTime() bool {
+	return c.DatetimePrecicion <= 3
+}
+
+// isNullable tells if the converted column has to be wrapped in Nullable, keys never are.
+func (c psqlColumnDefinition) isNullable() bool {
+	return c.IsNullable && !c.IsPrimaryKey && !c.IsGuessedPrimaryKey
+}
+
+const (
+	JSONStrategyString = "String"
+	JSONStrategyJSON   = "JSON"
+	JSONStrategyMap    = "Map"
+)
+
+const (
+	InetTypeIPv4 = "IPv4"
+	InetTypeIPv6 = "IPv6"
+)
+
+const (
+	EnumStrategyEnum           = "Enum"
+	EnumStrategyLowCardinality = "LowCardinality"
+)
+
+const (
+	TimeStrategyInt64 = "Int64"
+	TimeStrategyTime  = "Time"
+)
+
+const (
+	IntervalStrategyInt64  = "Int64"
+	IntervalStrategyString = "String"
+)
+
+const (
+	BinaryHandlingModeBytes  = "bytes"
+	BinaryHandlingModeBase64 = "base64"
+	BinaryHandlingModeHex    = "hex"
+)
+
+const (
+	GeometryTypePoint        = "Point"
+	GeometryTypeRing         = "Ring"
+	GeometryTypePolygon      = "Polygon"
+	GeometryTypeMultiPolygon = "MultiPolygon"
+)
+
+const (
+	GeometryFormatNative = "Native"
+	GeometryFormatWKT    = "WKT"
+)
+
+const (
+	RangeStrategyTuple = "Tuple"
+	RangeStrategySplit = "Split"
+)
+
+// postgreSqlRangeSubtypes maps the built-in range types to their bound type,
+// and the multirange types to their range type.
+var postgreSqlRangeSubtypes = map[string]string{
+	"int4range":      "int4",
+	"int8range":      "int8",
+	"numrange":       "numeric",
+	"tsrange":        "timestamp",
+	"tstzrange":      "timestamptz",
+	"daterange":      "date",
+	"int4multirange": "int4range",
+	"int8multirange": "int8range",
+	"nummultirange":  "numrange",
+	"tsmultirange":   "tsrange",
+	"tstzmultirange": "tstzrange",
+	"datemultirange": "daterange",
+}
+
+func isRangeType(psqlType string) bool {
+	_, ok := postgreSqlRangeSubtypes[psqlType]
+	return ok && !isMultirangeType(psqlType)
+}
+
+func isMultirangeType(psqlType string) bool {
+	return strings.HasSuffix(psqlType, "multirange")
+}
+
+const (
+	OnUnsupportedTypeError = "error"
+	OnUnsupportedTypeWarn  = "warn"
+	OnUnsupportedTypeSkip  = "skip"
+)
+
+func isJSONType(psqlType string) bool {
+	return psqlType == "json" || psqlType == "jsonb"
+}
+
+func isGeometryType(psqlType string) bool {
+	return psqlType == "geometry" || psqlType == "geography"
+}
+
+// canBeNullable returns false for the Clickhouse types that can't be wrapped in Nullable.
+func canBeNullable(clickhouseType string) bool {
+	for _, prefix := range []string{"Array(", "Map(", "Tuple(", "JSON", "LowCardinality(", "Point", "Ring", "Polygon", "MultiPolygon"} {
+		if strings.HasPrefix(clickhouseType, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+func postgreSqlToClickhouseType(column psqlColumnDefinition) (error, string) {
 	var err error
 	var clickhouseType string
-	switch psqlType {
+	// Arrays can't be Nullable in Clickhouse, the nullability is pushed down to the elements.
+	if element, ok := column.elementDefinition(); ok {
+		err, clickhouseType = postgreSqlToClickhouseType(element)
+		if err != nil {
+			err = &NotImplementedType{
+				PSQLType: column.Type,
+			}
+			return err, ""
+		}
+		return err, "Array(" + clickhouseType + ")"
+	}
+	// PostgreSQL composite types are user defined types, they are recognized by their fields.
+	if len(column.Fields) > 0 {
+		var fieldTypes []string
+		for _, field := range column.Fields {
+			err, clickhouseType = postgreSqlToClickhouseType(field)
+			if err != nil {
+				return err, ""
+			}
+			fieldTypes = append(fieldTypes, field.Name+" "+clickhouseType)
+		}
+		return err, "Tuple(" + strings.Join(fieldTypes, ", ") + ")"
+	}
+	// Ranges are tuples, bounds are Nullable as ranges can be unbounded.
+	if subtype, ok := column.rangeSubtypeDefinition(); ok {
+		err, clickhouseType = postgreSqlToClickhouseType(subtype)
+		if err != nil {
+			return err, ""
+		}
+		if isMultirangeType(column.Type) {
+			return err, "Array(" + clickhouseType + ")"
+		}
+		return err, fmt.Sprintf("Tuple(lower %s, upper %s, bounds String)", clickhouseType, clickhouseType)
+	}
+	// PostgreSQL enums are user defined types, they are recognized by their labels.
+	if len(column.EnumValues) > 0 {
+		clickhouseType = clickhouseEnumType(column.EnumValues)
+		if column.EnumStrategy == EnumStrategyLowCardinality {
+			clickhouseType = "String"
+			if column.isNullable() {
+				clickhouseType = "Nullable(" + clickhouseType + ")"
+			}
+			return err, "LowCardinality(" + clickhouseType + ")"
+		}
+		if column.isNullable() {
+			clickhouseType = "Nullable(" + clickhouseType + ")"
+		}
+		return err, clickhouseType
+	}
+	switch column.Type {
 	case "int2":
 		clickhouseType = "Int16"
 	case "int4":
@@ -294,38 +1558,102 @@ func postgreSqlToClickhouseType(psqlType string, numericPrecision int64, numeric
 	case "int8":
 		clickhouseType = "Int64"
 	case "numeric":
+		numericPrecision := column.NumericPrecision
+		numericScale := column.NumericScale
 		if numericPrecision == 0 {
 			numericPrecision = 38
 			numericScale = 19
 		}
 		clickhouseType = fmt.Sprintf("Decimal(%d, %d)", numericPrecision, numericScale)
-	case "varchar", "text", "bpchar":
+	case "varchar", "text", "bpchar", "citext":
 		clickhouseType = "String"
-	case "timestamp", "timestamptz":
-		clickhouseType = fmt.Sprintf("DateTime64(%d)", datetimePrecicion)
+	case "timestamp":
+		clickhouseType = fmt.Sprintf("DateTime64(%d)", column.DatetimePrecicion)
+	case "timestamptz":
+		clickhouseType = fmt.Sprintf("DateTime64(%d, %s)", column.DatetimePrecicion, quoteClickhouseString(column.Timezone))
 	case "date":
 		clickhouseType = "Date"
+	case "time":
+		if column.TimeStrategy == TimeStrategyTime {
+			clickhouseType = fmt.Sprintf("Time64(%d)", column.DatetimePrecicion)
+		} else {
+			clickhouseType = "Int64"
+		}
+	case "timetz":
+		clickhouseType = "String"
+	case "interval":
+		if column.IntervalStrategy == IntervalStrategyString {
+			clickhouseType = "String"
+		} else {
+			clickhouseType = "Int64"
+		}
 	case "float4":
 		clickhouseType = "Float32"
 	case "float8":
 		clickhouseType = "Float64"
 	case "bool":
 		clickhouseType = "Bool"
+	case "json", "jsonb":
+		switch column.JSONStrategy {
+		case JSONStrategyJSON:
+			clickhouseType = "JSON"
+		case JSONStrategyMap:
+			clickhouseType = "Map(String, String)"
+		default:
+			clickhouseType = "String"
+		}
+	case "bytea", "varbit":
+		clickhouseType = "String"
+	case "bit":
+		clickhouseType = clickhouseBitType(column.CharacterMaximumLength)
+	case "hstore":
+		clickhouseType = "Map(String, Nullable(String))"
+	case "geometry", "geography":
+		if column.GeometryType == "" || column.GeometryFormat == GeometryFormatWKT {
+			clickhouseType = "String"
+		} else {
+			clickhouseType = column.GeometryType
+		}
+	case "uuid":
+		clickhouseType = "UUID"
+	case "inet":
+		clickhouseType = column.InetType
+	case "cidr", "macaddr", "macaddr8":
+		clickhouseType = "String"
 	default:
 		err = &NotImplementedType{
-			PSQLType: psqlType,
+			PSQLType: column.Type,
 		}
 		return err, clickhouseType
 	}
-	if isNullable && !isPrimaryKey && !isGuessedPrimaryKey {
+	if column.isNullable() && canBeNullable(clickhouseType) {
 		clickhouseType = "Nullable(" + clickhouseType + ")"
 	}
 	return err, clickhouseType
 }
 
-func postgreSqlToKafkaEngineClickhouseType(psqlType string, datetimePrecicion int64, isNullable bool, isPrimaryKey bool, isGuessedPrimaryKey bool) string {
+func postgreSqlToKafkaEngineClickhouseType(column psqlColumnDefinition) (error, string) {
+	var err error
 	var clickhouseType string
-	switch psqlType {
+	if element, ok := column.elementDefinition(); ok {
+		err, clickhouseType = postgreSqlToKafkaEngineClickhouseType(element)
+		if err != nil {
+			err = &NotImplementedType{
+				PSQLType: column.Type,
+			}
+			return err, ""
+		}
+		return err, "Array(" + clickhouseType + ")"
+	}
+	if len(column.EnumValues) > 0 || len(column.Fields) > 0 {
+		// Debezium sends enum labels and composite values as strings
+		clickhouseType = "String"
+		if column.isNullable() {
+			clickhouseType = "Nullable(" + clickhouseType + ")"
+		}
+		return err, clickhouseType
+	}
+	switch column.Type {
 	case "int2":
 		clickhouseType = "Int16"
 	case "int4":
@@ -334,57 +1662,385 @@ func postgreSqlToKafkaEngineClickhouseType(psqlType string, datetimePrecicion in
 		clickhouseType = "Int64"
 	case "numeric":
 		clickhouseType = "String"
-	case "varchar", "text", "bpchar":
+	case "varchar", "text", "bpchar", "citext":
 		clickhouseType = "String"
 	case "timestamp":
-		clickhouseType = fmt.Sprintf("DateTime64(%d)", datetimePrecicion)
+		clickhouseType = fmt.Sprintf("DateTime64(%d)", column.DatetimePrecicion)
 	case "timestamptz":
 		clickhouseType = "String"
 	case "date":
 		clickhouseType = "Date"
+	case "time":
+		// Debezium Time or MicroTime, milliseconds or microseconds since midnight
+		clickhouseType = "Int64"
+	case "timetz":
+		// Debezium ZonedTime, ISO 8601 string with the offset
+		clickhouseType = "String"
+	case "interval":
+		if column.IntervalStrategy == IntervalStrategyString {
+			clickhouseType = "String"
+		} else {
+			// Debezium MicroDuration, microseconds
+			clickhouseType = "Int64"
+		}
 	case "float4":
 		clickhouseType = "Float32"
 	case "float8":
 		clickhouseType = "Float64"
 	case "bool":
 		clickhouseType = "Bool"
+	case "json", "jsonb":
+		// Debezium sends json documents as strings
+		clickhouseType = "String"
+	case "uuid", "inet", "cidr", "macaddr", "macaddr8":
+		clickhouseType = "String"
+	case "hstore":
+		// Debezium hstore.handling.mode json
+		clickhouseType = "String"
+	case "int4range", "int8range", "numrange", "tsrange", "tstzrange", "daterange",
+		"int4multirange", "int8multirange", "nummultirange", "tsmultirange", "tstzmultirange", "datemultirange":
+		// Debezium sends ranges in the PostgreSQL text format
+		clickhouseType = "String"
+	case "geometry", "geography":
+		// Debezium io.debezium.data.geometry.Geometry struct
+		clickhouseType = "Tuple(wkb String, srid Nullable(Int32))"
+	case "bytea", "varbit":
+		clickhouseType = "String"
+	case "bit":
+		if column.CharacterMaximumLength <= 1 {
+			// Debezium sends bit(1) as a boolean
+			clickhouseType = "Bool"
+		} else {
+			// Debezium sends bit(n) as little endian bytes
+			clickhouseType = "String"
+		}
 	default:
-		clickhouseType = "NotImplementedType!"
+		err = &NotImplementedType{
+			PSQLType: column.Type,
+		}
+		return err, clickhouseType
 	}
-	if isNullable && !isPrimaryKey && !isGuessedPrimaryKey {
+	if column.isNullable() && canBeNullable(clickhouseType) {
 		clickhouseType = "Nullable(" + clickhouseType + ")"
 	}
-	return clickhouseType
+	return err, clickhouseType
+}
+
+func mappingKafkaEngineTypes(column psqlColumnDefinition) types.String {
+	name := "`" + column.Name + "`"
+	expression := kafkaEngineValueExpression(name, column, 0)
+	if expression != name {
+		expression += " as " + name
+	}
+	return types.StringValue(expression)
+}
+
+// Composite values are received in the PostgreSQL text format, e.g. (1,"Main street, 5"),
+// fields holding commas, quotes, parentheses, backslashes or spaces being quoted. Once the
+// parentheses trimmed and a comma prepended, each field is matched with its leading comma.
+const (
+	compositeFieldPattern  = `,("(?:[^"\\]|\\.|"")*"|[^,]*)`
+	compositeEscapePattern = `[\\"](.)`
+)
+
+// compositeFieldExpression returns the value of a composite field written in the PostgreSQL
+// text format. An empty field is NULL while a quoted one, e.g. "foo bar" or "" for the empty
+// string, is unquoted, its "" and backslash escaped characters being unescaped.
+func compositeFieldExpression(field string) string {
+	return fmt.Sprintf(`if(startsWith(%s, '"'), replaceRegexpAll(substring(%s, 2, length(%s) - 2), %s, '\\1'), nullIf(%s, ''))`, field, field, field, quoteClickhouseString(compositeEscapePattern), field)
 }
 
-func mappingKafkaEngineTypes(name string, psqlType string) types.String {
-	var expression string
-	switch psqlType {
+// kafkaEngineValueExpression returns the expression converting value, read from the
+// kafka engine, to its Clickhouse base type. Array elements are converted with arrayMap,
+// depth is used to name the lambda variable of nested arrays.
+func kafkaEngineValueExpression(value string, column psqlColumnDefinition, depth int) string {
+	if element, ok := column.elementDefinition(); ok {
+		variable := fmt.Sprintf("x%d", depth)
+		elementExpression := kafkaEngineValueExpression(variable, element, depth+1)
+		if elementExpression == variable {
+			return value
+		}
+		return fmt.Sprintf("arrayMap(%s -> %s, %s)", variable, elementExpression, value)
+	}
+	if subtype, ok := column.rangeSubtypeDefinition(); ok {
+		if isMultirangeType(column.Type) {
+			// {[1,3),[5,7)} is split in ranges converted one by one
+			ranges := "extractAll(ifNull(" + value + `, ''), '[\\[(][^\\])]*[\\])]')`
+			variable := fmt.Sprintf("x%d", depth)
+			return fmt.Sprintf("arrayMap(%s -> %s, %s)", variable, kafkaEngineValueExpression(variable, subtype, depth+1), ranges)
+		}
+		_, tupleType := postgreSqlToClickhouseType(column)
+		rangeValue := "ifNull(" + value + ", '')"
+		bounds := fmt.Sprintf("if(%s IN ('', 'empty'), %s, concat(left(%s, 1), right(%s, 1)))", rangeValue, rangeValue, rangeValue, rangeValue)
+		return "CAST(tuple(" + rangeBoundExpression(value, column, 1) + ", " + rangeBoundExpression(value, column, 2) + ", " + bounds + ") AS " + tupleType + ")"
+	}
+	if len(column.Fields) > 0 {
+		_, tupleType := postgreSqlToClickhouseType(column)
+		fields := fmt.Sprintf("extractAllGroupsVertical(concat(',', trim(BOTH '()' FROM ifNull(%s, ''))), %s)", value, quoteClickhouseString(compositeFieldPattern))
+		var fieldValues []string
+		for i := range column.Fields {
+			fieldValues = append(fieldValues, compositeFieldExpression(fmt.Sprintf("%s[%d][1]", fields, i+1)))
+		}
+		return "CAST(tuple(" + strings.Join(fieldValues, ", ") + ") AS " + tupleType + ")"
+	}
+	if len(column.EnumValues) > 0 {
+		if column.EnumStrategy == EnumStrategyLowCardinality {
+			return value
+		}
+		enumType := clickhouseEnumType(column.EnumValues)
+		if column.isNullable() {
+			enumType = "Nullable(" + enumType + ")"
+		}
+		return "CAST(" + value + " AS " + enumType + ")"
+	}
+	switch column.Type {
 	case "timestamptz":
-		expression = "parseDateTime64BestEffortOrNull(`" + name + "`) as `" + name + "`"
+		return fmt.Sprintf("parseDateTime64BestEffortOrNull(%s, %d, %s)", value, column.DatetimePrecicion, quoteClickhouseString(column.Timezone))
+	case "time":
+		if column.isMilliTime() {
+			if column.TimeStrategy == TimeStrategyTime {
+				return fmt.Sprintf("toTime64(%s / 1000, %d)", value, column.DatetimePrecicion)
+			}
+			return value + " * 1000"
+		}
+		if column.TimeStrategy == TimeStrategyTime {
+			return fmt.Sprintf("toTime64(%s / 1000000, %d)", value, column.DatetimePrecicion)
+		}
+		return value
+	case "interval":
+		if column.IntervalStrategy == IntervalStrategyString {
+			return value
+		}
+		return "intDiv(" + value + ", 1000000)"
+	case "json", "jsonb":
+		switch column.JSONStrategy {
+		case JSONStrategyJSON:
+			return "CAST(ifNull(" + value + ", '{}'), 'JSON')"
+		case JSONStrategyMap:
+			return "JSONExtract(ifNull(" + value + ", '{}'), 'Map(String, String)')"
+		default:
+			return value
+		}
+	case "bytea":
+		switch column.BinaryHandlingMode {
+		case BinaryHandlingModeBase64:
+			return "base64Decode(" + value + ")"
+		case BinaryHandlingModeHex:
+			return "unhex(" + value + ")"
+		default:
+			return value
+		}
+	case "bit":
+		bitType := clickhouseBitType(column.CharacterMaximumLength)
+		if strings.HasPrefix(bitType, "UInt") && column.CharacterMaximumLength > 1 {
+			return "reinterpretAs" + bitType + "(" + value + ")"
+		}
+		return value
+	case "hstore":
+		return "JSONExtract(ifNull(" + value + ", '{}'), 'Map(String, Nullable(String))')"
+	case "geometry", "geography":
+		wkb := "tupleElement(" + value + ", 'wkb')"
+		if column.GeometryType == "" {
+			return "hex(" + wkb + ")"
+		}
+		// Rings are read as line strings, both are arrays of points
+		reader := "readWKB" + column.GeometryType
+		if column.GeometryType == GeometryTypeRing {
+			reader = "readWKBLineString"
+		}
+		if column.GeometryFormat == GeometryFormatWKT {
+			return "wkt(" + reader + "(" + wkb + "))"
+		}
+		return reader + "(" + wkb + ")"
+	case "uuid":
+		return "toUUID(" + value + ")"
+	case "inet":
+		// Drop the netmask, Clickhouse IP types only hold the address, arrays can't be Nullable
+		address := "splitByChar('/', ifNull(" + value + ", ''))[1]"
+		if column.InetType == InetTypeIPv4 {
+			return "toIPv4OrNull(" + address + ")"
+		}
+		return "toIPv6OrNull(" + address + ")"
 	default:
-		expression = "`" + name + "`"
+		return value
+	}
+}
+
+// rangeBoundExpression returns the expression extracting the lower (index 1) or upper (index 2)
+// bound of value, a range received in the PostgreSQL text format, e.g. [2024-01-01,2024-02-01).
+// Unbounded sides and empty ranges give NULL.
+func rangeBoundExpression(value string, column psqlColumnDefinition, index int) string {
+	subtype, _ := column.rangeSubtypeDefinition()
+	boundSubtype := subtype
+	boundSubtype.IsNullable = false
+	_, boundType := postgreSqlToClickhouseType(boundSubtype)
+	rangeValue := "ifNull(" + value + ", '')"
+	bound := fmt.Sprintf("replaceAll(splitByChar(',', substring(%s, 2, length(%s) - 2))[%d], '\"', '')", rangeValue, rangeValue, index)
+	switch subtype.Type {
+	case "timestamp":
+		return fmt.Sprintf("parseDateTime64BestEffortOrNull(%s, %d)", bound, subtype.DatetimePrecicion)
+	case "timestamptz":
+		return fmt.Sprintf("parseDateTime64BestEffortOrNull(%s, %d, %s)", bound, subtype.DatetimePrecicion, quoteClickhouseString(subtype.Timezone))
+	default:
+		return fmt.Sprintf("accurateCastOrNull(%s, %s)", bound, quoteClickhouseString(boundType))
+	}
+}
+
+// clickhouseDomainConstraint renders a PostgreSQL domain CHECK expression as a Clickhouse CONSTRAINT
+// on the column. NULL values pass PostgreSQL checks, they pass the Clickhouse constraint as well.
+func clickhouseDomainConstraint(column psqlColumnDefinition, name string, check string) string {
+	check = strings.TrimSpace(check)
+	checkClause := regexp.MustCompile(`(?is)^CHECK\s*\((.*)\)$`)
+	if checkClause.MatchString(check) {
+		check = strings.TrimSpace(checkClause.FindStringSubmatch(check)[1])
+	}
+	expression := regexp.MustCompile(`(?i)\bVALUE\b`).ReplaceAllLiteralString(check, "`"+column.Name+"`")
+	if column.isNullable() {
+		expression = "isNull(`" + column.Name + "`) OR (" + expression + ")"
+	}
+	return "CONSTRAINT `" + name + "` CHECK " + expression
+}
+
+// clickhouseBitType returns the smallest Clickhouse unsigned integer holding a bit(length),
+// longer bit strings are kept as bytes in a FixedString.
+func clickhouseBitType(length int64) string {
+	switch {
+	case length <= 8:
+		return "UInt8"
+	case length <= 16:
+		return "UInt16"
+	case length <= 32:
+		return "UInt32"
+	case length <= 64:
+		return "UInt64"
+	default:
+		return fmt.Sprintf("FixedString(%d)", (length+7)/8)
+	}
+}
+
+// clickhouseEnumType returns the smallest Clickhouse enum holding the labels,
+// values are numbered from 1 following the PostgreSQL declaration order.
+func clickhouseEnumType(labels []string) string {
+	enumType := "Enum8"
+	if len(labels) > math.MaxInt8 {
+		enumType = "Enum16"
+	}
+	var values []string
+	for i, label := range labels {
+		values = append(values, fmt.Sprintf("%s = %d", quoteClickhouseString(label), i+1))
+	}
+	return enumType + "(" + strings.Join(values, ", ") + ")"
+}
+
+// quoteClickhouseString returns value as a single quoted Clickhouse string literal.
+func quoteClickhouseString(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+// postgreSqlToAthenaType returns the Athena type of the column, the PostgreSQL column is used
+// when the Clickhouse type alone is ambiguous.
+func postgreSqlToAthenaType(column psqlColumnDefinition, clickhouseType string) (error, string) {
+	var err error
+	var athenaType string
+	if element, ok := column.elementDefinition(); ok {
+		array := regexp.MustCompile(`^Array\((?P<Type>.+)\)$`)
+		if array.MatchString(clickhouseType) {
+			matches := array.FindStringSubmatch(clickhouseType)
+			err, athenaType = postgreSqlToAthenaType(element, matches[array.SubexpIndex("Type")])
+			if err != nil {
+				return err, ""
+			}
+			return err, "array<" + athenaType + ">"
+		}
+	}
+	if subtype, ok := column.rangeSubtypeDefinition(); ok {
+		_, subtypeClickhouseType := postgreSqlToClickhouseType(subtype)
+		err, athenaType = postgreSqlToAthenaType(subtype, subtypeClickhouseType)
+		if err != nil {
+			return err, ""
+		}
+		if isMultirangeType(column.Type) {
+			return err, "array<" + athenaType + ">"
+		}
+		return err, "struct<lower:" + athenaType + ",upper:" + athenaType + ",bounds:string>"
+	}
+	switch {
+	case len(column.Fields) > 0:
+		var fieldTypes []string
+		for _, field := range column.Fields {
+			_, fieldClickhouseType := postgreSqlToClickhouseType(field)
+			err, athenaType = postgreSqlToAthenaType(field, fieldClickhouseType)
+			if err != nil {
+				return err, ""
+			}
+			fieldTypes = append(fieldTypes, field.Name+":"+athenaType)
+		}
+		return err, "struct<" + strings.Join(fieldTypes, ",") + ">"
+	case column.Type == "time" && column.TimeStrategy != TimeStrategyTime:
+		// Microseconds since midnight overflow the Athena int
+		return err, "bigint"
+	case isJSONType(column.Type) && column.JSONStruct != "":
+		return err, column.JSONStruct
+	case column.Type == "bytea":
+		return err, "binary"
+	case isGeometryType(column.Type):
+		if clickhouseType == "String" || clickhouseType == "Nullable(String)" {
+			return err, "string"
+		}
+		return err, "binary"
+	default:
+		return clickhouseToAthena(clickhouseType)
 	}
-	return types.StringValue(expression)
 }
 
-func clickhouseToAthena(clichouseType string) string {
-	nullable := regexp.MustCompile(`Nullable\((?P<Type>.+)\)`)
+func clickhouseToAthena(clichouseType string) (error, string) {
+	var err error
+	nullable := regexp.MustCompile(`^Nullable\((?P<Type>.+)\)$`)
 	if nullable.MatchString(clichouseType) {
 		matches := nullable.FindStringSubmatch(clichouseType)
 		clichouseType = matches[nullable.SubexpIndex("Type")]
 	}
-	decimalPS := regexp.MustCompile(`Decimal\((?P<Precision>\d+), (?P<Scale>\d+)\)`)
-	decimalP := regexp.MustCompile(`Decimal\((?P<Precision>\d+)\)`)
+	array := regexp.MustCompile(`^Array\((?P<Type>.+)\)$`)
+	lowCardinality := regexp.MustCompile(`^LowCardinality\((?P<Type>.+)\)$`)
+	enum := regexp.MustCompile(`^Enum(8|16)\(.*\)$`)
+	mapKV := regexp.MustCompile(`^Map\((?P<Key>[^,]+), (?P<Value>.+)\)$`)
+	decimalPS := regexp.MustCompile(`^Decimal\((?P<Precision>\d+), (?P<Scale>\d+)\)$`)
+	decimalP := regexp.MustCompile(`^Decimal\((?P<Precision>\d+)\)$`)
 	var athenaType string
 	switch {
+	case array.MatchString(clichouseType):
+		matches := array.FindStringSubmatch(clichouseType)
+		err, athenaType = clickhouseToAthena(matches[array.SubexpIndex("Type")])
+		athenaType = "array<" + athenaType + ">"
+	case lowCardinality.MatchString(clichouseType):
+		matches := lowCardinality.FindStringSubmatch(clichouseType)
+		err, athenaType = clickhouseToAthena(matches[lowCardinality.SubexpIndex("Type")])
+	case enum.MatchString(clichouseType):
+		athenaType = "string"
+	case mapKV.MatchString(clichouseType):
+		matches := mapKV.FindStringSubmatch(clichouseType)
+		keyErr, key := clickhouseToAthena(matches[mapKV.SubexpIndex("Key")])
+		valueErr, value := clickhouseToAthena(matches[mapKV.SubexpIndex("Value")])
+		err = keyErr
+		if err == nil {
+			err = valueErr
+		}
+		athenaType = fmt.Sprintf("map<%s,%s>", key, value)
 	case clichouseType == "Int16":
 		athenaType = "int"
 	case clichouseType == "Int32":
 		athenaType = "int"
 	case clichouseType == "Int64":
 		athenaType = "int"
-	case clichouseType == "String":
+	case clichouseType == "UInt8", clichouseType == "UInt16":
+		athenaType = "int"
+	case clichouseType == "UInt32", clichouseType == "UInt64":
+		athenaType = "bigint"
+	case regexp.MustCompile(`^FixedString\(\d+\)$`).MatchString(clichouseType):
+		athenaType = "binary"
+	case clichouseType == "String", clichouseType == "JSON":
+		athenaType = "string"
+	case clichouseType == "UUID", clichouseType == "IPv4", clichouseType == "IPv6":
 		athenaType = "string"
 	case decimalPS.MatchString(clichouseType):
 		matches := decimalPS.FindStringSubmatch(clichouseType)
@@ -395,8 +2051,11 @@ func clickhouseToAthena(clichouseType string) string {
 		matches := decimalP.FindStringSubmatch(clichouseType)
 		precision := matches[decimalP.SubexpIndex("Precision")]
 		athenaType = fmt.Sprintf("decimal(%s)", precision)
-	case regexp.MustCompile(`DateTime64\(\d+\)`).MatchString(clichouseType):
+	case regexp.MustCompile(`^DateTime64\(\d+(, '[^']*')?\)$`).MatchString(clichouseType),
+		regexp.MustCompile(`^DateTime(\('[^']*'\))?$`).MatchString(clichouseType):
 		athenaType = "timestamp"
+	case regexp.MustCompile(`^Time64\(\d+\)$`).MatchString(clichouseType):
+		athenaType = "string"
 	case clichouseType == "Date":
 		athenaType = "date"
 	case clichouseType == "Float32":
@@ -406,8 +2065,13 @@ func clickhouseToAthena(clichouseType string) string {
 	case clichouseType == "Bool":
 		athenaType = "boolean"
 	default:
-		athenaType = "NotImplementedType!"
+		err = &NotImplementedClickhouseType{
+			ClickhouseType: clichouseType,
+		}
+	}
+	if err != nil {
+		return err, ""
 	}
-	return athenaType
+	return err, athenaType
 
 }
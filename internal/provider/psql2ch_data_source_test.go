@@ -4,6 +4,11 @@
 package provider
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -68,6 +73,589 @@ func TestAccPsql2ChDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.4.type", "int"),
 				),
 			},
+			// Test array columns
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseArrays,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "Array(Nullable(Int32))"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.2.type", "Array(Nullable(String))"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.3.type", "Array(Array(Nullable(DateTime64(6, 'UTC'))))"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.3.type", "Array(Array(Nullable(String)))"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.2", "`tags`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.3", "arrayMap(x0 -> arrayMap(x1 -> parseDateTime64BestEffortOrNull(x1, 6, 'UTC'), x0), `slots`) as `slots`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.1.type", "array<int>"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.2.type", "array<string>"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.3.type", "array<array<timestamp>>"),
+				),
+			},
+			// Test json columns
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseJSON,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.2.type", "Map(String, String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.3.type", "JSON"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.2.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.1", "`payload`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.2", "JSONExtract(ifNull(`attributes`, '{}'), 'Map(String, String)') as `attributes`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.3", "CAST(ifNull(`document`, '{}'), 'JSON') as `document`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.1.type", "string"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.2.type", "map<string,string>"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.3.type", "struct<id:int,name:string>"),
+				),
+			},
+			// Test uuid and network address columns
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseNetwork,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.0.type", "UUID"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "Nullable(IPv4)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.2.type", "IPv6"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.3.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.0.type", "String"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.1.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.0", "toUUID(`request_id`) as `request_id`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.1", "toIPv4OrNull(splitByChar('/', ifNull(`client_ip`, ''))[1]) as `client_ip`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.2", "toIPv6OrNull(splitByChar('/', ifNull(`server_ip`, ''))[1]) as `server_ip`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.3", "`network`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.0.type", "string"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.1.type", "string"),
+				),
+			},
+			// Test enum columns
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseEnum,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "Nullable(Enum8('pending' = 1, 'paid' = 2, 'refunded' = 3))"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.2.type", "LowCardinality(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.1.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.1", "CAST(`status` AS Nullable(Enum8('pending' = 1, 'paid' = 2, 'refunded' = 3))) as `status`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.2", "`currency`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.1.type", "string"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.2.type", "string"),
+				),
+			},
+			// Test time and interval columns
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseTime(`time_strategy = "Time"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "Nullable(Time64(3))"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.2.type", "String"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.3.type", "Nullable(Int64)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.1.type", "Nullable(Int64)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.3.type", "Nullable(Int64)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.1", "toTime64(`opens_at` / 1000, 3) as `opens_at`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.4", "toTime64(`starts_at` / 1000000, 6) as `starts_at`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.2", "`closes_at`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.3", "intDiv(`sla`, 1000000) as `sla`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.1.type", "string"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.2.type", "string"),
+				),
+			},
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseTime(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "Nullable(Int64)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.1", "`opens_at` * 1000 as `opens_at`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.4", "`starts_at`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.1.type", "bigint"),
+				),
+			},
+			// Test timezone aware timestamps
+			{
+				Config:      strings.Replace(testAccPsql2ChDataSourceConfigCaseTimezone, `"Europe/Paris"`, `"Europe/Pariss"`, 1),
+				ExpectError: regexp.MustCompile(`Invalid Timezone`),
+			},
+			{
+				Config:      strings.Replace(testAccPsql2ChDataSourceConfigCaseTimezone, `"America/New_York"`, `"America/New_Yrok"`, 1),
+				ExpectError: regexp.MustCompile(`Invalid Timezone`),
+			},
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseTimezone,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "DateTime64(6)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.2.type", "Nullable(DateTime64(3, 'Europe/Paris'))"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.3.type", "Nullable(DateTime64(6, 'America/New_York'))"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.2.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.2", "parseDateTime64BestEffortOrNull(`updated_at`, 3, 'Europe/Paris') as `updated_at`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.1.type", "timestamp"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.2.type", "timestamp"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.3.type", "timestamp"),
+				),
+			},
+			// Test binary columns
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseBinary,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.2.type", "UInt16"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.3.type", "Nullable(FixedString(16))"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.4.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.2.type", "String"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.1", "unhex(`document_hash`) as `document_hash`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.2", "reinterpretAsUInt16(`flags`) as `flags`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.3", "`bitmap`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.1.type", "binary"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.2.type", "int"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.3.type", "binary"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.4.type", "string"),
+				),
+			},
+			// Test hstore and composite columns
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseComposite,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "Map(String, Nullable(String))"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.2.type", "Tuple(street Nullable(String), zip_code Nullable(Int32))"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.1.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.2.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.1", "JSONExtract(ifNull(`attributes`, '{}'), 'Map(String, Nullable(String))') as `attributes`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.2", "CAST(tuple(if(startsWith(extractAllGroupsVertical(concat(',', trim(BOTH '()' FROM ifNull(`address`, ''))), ',(\"(?:[^\"\\\\\\\\]|\\\\\\\\.|\"\")*\"|[^,]*)')[1][1], '\"'), replaceRegexpAll(substring(extractAllGroupsVertical(concat(',', trim(BOTH '()' FROM ifNull(`address`, ''))), ',(\"(?:[^\"\\\\\\\\]|\\\\\\\\.|\"\")*\"|[^,]*)')[1][1], 2, length(extractAllGroupsVertical(concat(',', trim(BOTH '()' FROM ifNull(`address`, ''))), ',(\"(?:[^\"\\\\\\\\]|\\\\\\\\.|\"\")*\"|[^,]*)')[1][1]) - 2), '[\\\\\\\\\"](.)', '\\\\1'), nullIf(extractAllGroupsVertical(concat(',', trim(BOTH '()' FROM ifNull(`address`, ''))), ',(\"(?:[^\"\\\\\\\\]|\\\\\\\\.|\"\")*\"|[^,]*)')[1][1], '')), if(startsWith(extractAllGroupsVertical(concat(',', trim(BOTH '()' FROM ifNull(`address`, ''))), ',(\"(?:[^\"\\\\\\\\]|\\\\\\\\.|\"\")*\"|[^,]*)')[2][1], '\"'), replaceRegexpAll(substring(extractAllGroupsVertical(concat(',', trim(BOTH '()' FROM ifNull(`address`, ''))), ',(\"(?:[^\"\\\\\\\\]|\\\\\\\\.|\"\")*\"|[^,]*)')[2][1], 2, length(extractAllGroupsVertical(concat(',', trim(BOTH '()' FROM ifNull(`address`, ''))), ',(\"(?:[^\"\\\\\\\\]|\\\\\\\\.|\"\")*\"|[^,]*)')[2][1]) - 2), '[\\\\\\\\\"](.)', '\\\\1'), nullIf(extractAllGroupsVertical(concat(',', trim(BOTH '()' FROM ifNull(`address`, ''))), ',(\"(?:[^\"\\\\\\\\]|\\\\\\\\.|\"\")*\"|[^,]*)')[2][1], ''))) AS Tuple(street Nullable(String), zip_code Nullable(Int32))) as `address`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.1.type", "map<string,string>"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.2.type", "struct<street:string,zip_code:int>"),
+				),
+			},
+			// Test PostGIS columns
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseGeometry,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "Point"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.2.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.3.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.1.type", "Tuple(wkb String, srid Nullable(Int32))"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.1", "readWKBPoint(tupleElement(`position`, 'wkb')) as `position`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.2", "wkt(readWKBMultiPolygon(tupleElement(`area`, 'wkb'))) as `area`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.3", "hex(tupleElement(`shape`, 'wkb')) as `shape`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.1.type", "binary"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.2.type", "string"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.3.type", "string"),
+				),
+			},
+			// Test range columns
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseRange,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "Tuple(lower Nullable(DateTime64(6, 'UTC')), upper Nullable(DateTime64(6, 'UTC')), bounds String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.2.name", "validity_lower"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.2.type", "Nullable(Date)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.3.name", "validity_upper"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.3.type", "Nullable(Date)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.4.type", "Array(Tuple(lower Nullable(Int32), upper Nullable(Int32), bounds String))"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.#", "4"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.2.name", "validity"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.2.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.2", "accurateCastOrNull(replaceAll(splitByChar(',', substring(ifNull(`validity`, ''), 2, length(ifNull(`validity`, '')) - 2))[1], '\"', ''), 'Date') as `validity_lower`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.1.type", "struct<lower:timestamp,upper:timestamp,bounds:string>"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.2.name", "validity_lower"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.2.type", "date"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.4.type", "array<struct<lower:int,upper:int,bounds:string>>"),
+				),
+			},
+			// Test PostgreSQL type spellings
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseTypeSpellings,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.0.type", "Int32"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.2.type", "Nullable(DateTime64(3, 'UTC'))"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.3.type", "Nullable(Decimal(12, 2))"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.4.type", "Nullable(Decimal(10, 4))"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.5.type", "Array(Nullable(Int64))"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.3.type", "decimal(12,2)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.6.type", "Nullable(DateTime64(6, 'UTC'))"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.6", "parseDateTime64BestEffortOrNull(`updated_at`, 6, 'UTC') as `updated_at`"),
+				),
+			},
+			// Test domain columns
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseDomain,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "String"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.2.type", "Nullable(Decimal(12, 2))"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.2.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.1.type", "string"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.2.type", "decimal(12,2)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_constraints.#", "1"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_constraints.0", "CONSTRAINT `amount_positive_amount_1` CHECK isNull(`amount`) OR (`amount` > 0)"),
+				),
+			},
+			// Test unsupported types
+			{
+				Config:      testAccPsql2ChDataSourceConfigCaseUnsupportedType(""),
+				ExpectError: regexp.MustCompile(`Type tsvector not implemented`),
+			},
+			{
+				Config:      testAccPsql2ChDataSourceConfigCaseUnsupportedTypes,
+				ExpectError: regexp.MustCompile(`(?s)Unable to map PostgreSQL type.*tsvector.*Unable to map PostgreSQL type.*pg_lsn`),
+			},
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseUnsupportedType(`on_unsupported_type = "warn"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.#", "2"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.1.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.1.type", "string"),
+				),
+			},
+			{
+				Config:      testAccPsql2ChDataSourceConfigCaseUnsupportedTypeOverride,
+				ExpectError: regexp.MustCompile(`Unable to map PostgreSQL type`),
+			},
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseUnsupportedType(`on_unsupported_type = "skip"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.#", "1"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.#", "1"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.#", "1"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.#", "1"),
+				),
+			},
+			// Test column overrides
+			{
+				Config:      testAccPsql2ChDataSourceConfigCaseInvalidOverride,
+				ExpectError: regexp.MustCompile(`Invalid Clickhouse Type`),
+			},
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseOverrides,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.0.type", "UInt64"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.0.type", "Int64"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.0", "toUInt64(`key_id`) as `key_id`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.0.type", "bigint"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "LowCardinality(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.1.type", "varchar(32)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.2.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.2.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.2", "`search`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.2.type", "string"),
+				),
+			},
+			// Test provider type mapping rules
+			{
+				Config:      testAccPsql2ChDataSourceConfigCaseInvalidTypeMapping,
+				ExpectError: regexp.MustCompile(`Invalid Type Mapping Rule`),
+			},
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseTypeMapping,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.0.type", "UInt64"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.0.type", "Int64"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.0", "toUInt64(`key_id`) as `key_id`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.1.type", "string"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.2.type", "Nullable(Decimal(12, 2))"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.3.type", "UInt32"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.4.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.4.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.4.type", "string"),
+				),
+			},
+			// Test primary key guessing
+			{
+				Config: testAccPsql2ChDataSourceConfigCasePrimaryKeyGuessing(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_guessed_primarykey.0", "product_history_change_id"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.0.type", "Nullable(Int64)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "Int64"),
+				),
+			},
+			{
+				Config: testAccPsql2ChDataSourceConfigCasePrimaryKeyGuessing(`primary_key_guessing = { patterns = ["^product_id$"] }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_guessed_primarykey.0", "product_id"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.0.type", "Int64"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "Nullable(Int64)"),
+				),
+			},
+			{
+				Config: testAccPsql2ChDataSourceConfigCasePrimaryKeyGuessing(`primary_key_guessing = { enabled = false }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.datatools_psql2ch.test", "clickhouse_guessed_primarykey.0"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "Nullable(Int64)"),
+				),
+			},
+			{
+				Config: testAccPsql2ChDataSourceConfigCasePrimaryKeyGuessingDeclared,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_primarykey.0", "change_uuid"),
+					resource.TestCheckNoResourceAttr("data.datatools_psql2ch.test", "clickhouse_guessed_primarykey.0"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "Nullable(Int64)"),
+				),
+			},
+			// Test table metadata
+			{
+				Config:      testAccPsql2ChDataSourceConfigCaseTableMetadata(`"sku", "missing"`),
+				ExpectError: regexp.MustCompile(`Unknown Constraint Column`),
+			},
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseTableMetadata(`"tenant", "sku"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.datatools_psql2ch.test", "id", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_table_name", "shop_product"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_guessed_primarykey.#", "2"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_guessed_primarykey.0", "tenant"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_guessed_primarykey.1", "sku"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.0.type", "Nullable(Int64)"),
+				),
+			},
+			// Test create table
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseCDC(`
+	clickhouse_table = {
+		order_by = ["name", "id"]
+	}`, "name"),
+				ExpectError: regexp.MustCompile(`Invalid Sorting Key`),
+			},
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseCreateTable,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_create_table", "CREATE TABLE `analytics`.`order`\n(\n"+
+						"    `id` Int64,\n"+
+						"    `created_at` DateTime64(6, 'UTC'),\n"+
+						"    `note` Nullable(String) COMMENT 'Customer\\'s note'\n"+
+						")\n"+
+						"ENGINE = MergeTree\n"+
+						"PARTITION BY toYYYYMM(`created_at`)\n"+
+						"ORDER BY `id`\n"+
+						"SETTINGS index_granularity = 8192, storage_policy = 'hot'\n"+
+						"COMMENT 'Orders'\n"),
+				),
+			},
+			// Test ReplacingMergeTree CDC strategy
+			{
+				Config:      testAccPsql2ChDataSourceConfigCaseCDC(`cdc_strategy = "ReplacingMergeTree"`, "_version"),
+				ExpectError: regexp.MustCompile(`Conflicting CDC Column`),
+			},
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseCDC(`cdc_strategy = "ReplacingMergeTree"`, "name"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.#", "4"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.2.name", "_version"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.2.type", "UInt64"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.3.name", "_is_deleted"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.3.type", "UInt8"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.#", "4"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.2.name", "__op"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.3.name", "__lsn"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.2", "toUInt64(ifNull(`__lsn`, 0)) as `_version`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.3", "if(`__op` = 'd', 1, 0) as `_is_deleted`"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.#", "4"),
+					resource.TestMatchResourceAttr("data.datatools_psql2ch.test", "clickhouse_create_table", regexp.MustCompile("(?s)`_is_deleted` UInt8\n\\)\nENGINE = ReplacingMergeTree\\(`_version`, `_is_deleted`\\)\nORDER BY `id`\n$")),
+				),
+			},
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseCDC(`
+	cdc_strategy = "ReplacingMergeTree"
+	cdc_version  = "ts_ms"
+	clickhouse_table = {
+		engine = "ReplacingMergeTree(`+"`_version`"+`)"
+	}`, "name"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.3.name", "__ts_ms"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns_mapping.2", "toUInt64(ifNull(`__ts_ms`, 0)) as `_version`"),
+					resource.TestMatchResourceAttr("data.datatools_psql2ch.test", "clickhouse_create_table", regexp.MustCompile("ENGINE = ReplacingMergeTree\\(`_version`\\)\n")),
+				),
+			},
+			// Test kafka engine table
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseCDC(`
+	kafka_engine = {
+		broker_list         = ["kafka-1:9092", "kafka-2:9092"]
+		topic_list          = ["pg.public.customer"]
+		group_name          = "clickhouse-customer"
+		schema_registry_url = "http://schema-registry:8081"
+		num_consumers       = 2
+		handle_error_mode   = "stream"
+	}`, "name"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_create_table", "CREATE TABLE `customer_kafka`\n(\n"+
+						"    `id` Int64,\n"+
+						"    `name` Nullable(String)\n"+
+						")\n"+
+						"ENGINE = Kafka\n"+
+						"SETTINGS kafka_broker_list = 'kafka-1:9092,kafka-2:9092', kafka_topic_list = 'pg.public.customer', kafka_group_name = 'clickhouse-customer', "+
+						"kafka_format = 'AvroConfluent', format_avro_schema_registry_url = 'http://schema-registry:8081', kafka_num_consumers = 2, kafka_handle_error_mode = 'stream'\n"),
+				),
+			},
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseCDC("", "name"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_create_table"),
+				),
+			},
+			// Test materialized view
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseCDC(`
+	materialized_view = {
+		virtual_columns = {
+			_offset = "name"
+		}
+	}`, "name"),
+				ExpectError: regexp.MustCompile(`Conflicting Audit Column`),
+			},
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseCDC(`
+	cdc_strategy = "ReplacingMergeTree"
+	clickhouse_table = {
+		database = "raw"
+	}
+	kafka_engine = {
+		broker_list = ["kafka:9092"]
+		topic_list  = ["pg.public.customer"]
+		group_name  = "clickhouse-customer"
+	}
+	materialized_view = {
+		where = ["isNotNull(`+"`id`"+`)"]
+		virtual_columns = {
+			_topic     = "_kafka_topic"
+			_partition = "_kafka_partition"
+			_offset    = "_kafka_offset"
+			_timestamp = "_kafka_timestamp"
+		}
+	}`, "name"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.#", "8"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.4.name", "_kafka_topic"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.4.type", "LowCardinality(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.7.name", "_kafka_timestamp"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "athena_columns.#", "8"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_columns.#", "4"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_create_materialized_view", "CREATE MATERIALIZED VIEW `raw`.`customer_mv` TO `raw`.`customer`\n"+
+						"AS SELECT\n"+
+						"    `id`,\n"+
+						"    `name`,\n"+
+						"    toUInt64(ifNull(`__lsn`, 0)) as `_version`,\n"+
+						"    if(`__op` = 'd', 1, 0) as `_is_deleted`,\n"+
+						"    _topic as `_kafka_topic`,\n"+
+						"    _partition as `_kafka_partition`,\n"+
+						"    _offset as `_kafka_offset`,\n"+
+						"    _timestamp as `_kafka_timestamp`\n"+
+						"FROM `raw`.`customer_kafka`\n"+
+						"WHERE isNotNull(`id`)\n"),
+				),
+			},
+			// Test cluster
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseCDC(`
+	cdc_strategy = "ReplacingMergeTree"
+	clickhouse_table = {
+		database = "raw"
+	}
+	cluster = {
+		name = "main"
+	}
+	kafka_engine = {
+		broker_list = ["kafka:9092"]
+		topic_list  = ["pg.public.customer"]
+		group_name  = "clickhouse-customer"
+	}
+	materialized_view = {}`, "name"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_create_distributed_table", "CREATE TABLE `raw`.`customer` ON CLUSTER `main` AS `raw`.`customer_local`\n"+
+						"ENGINE = Distributed('main', 'raw', 'customer_local', cityHash64(`id`))\n"),
+					resource.TestMatchResourceAttr("data.datatools_psql2ch.test", "clickhouse_create_table", regexp.MustCompile("^CREATE TABLE `raw`.`customer_local` ON CLUSTER `main`\n")),
+					resource.TestMatchResourceAttr("data.datatools_psql2ch.test", "clickhouse_create_table", regexp.MustCompile(
+						"ENGINE = ReplicatedReplacingMergeTree\\('/clickhouse/tables/\\{shard\\}/\\{database\\}/\\{table\\}', '\\{replica\\}', `_version`, `_is_deleted`\\)\n")),
+					resource.TestMatchResourceAttr("data.datatools_psql2ch.test", "clickhouse_kafkaengine_create_table", regexp.MustCompile("^CREATE TABLE `raw`.`customer_kafka` ON CLUSTER `main`\n")),
+					resource.TestMatchResourceAttr("data.datatools_psql2ch.test", "clickhouse_create_materialized_view", regexp.MustCompile("^CREATE MATERIALIZED VIEW `raw`.`customer_mv` ON CLUSTER `main` TO `raw`.`customer`\n")),
+				),
+			},
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseCDC(`
+	cluster = {
+		name               = "main"
+		zookeeper_path     = "/tables/{cluster}/{shard}/customer"
+		replica_name       = "{replica}-{shard}"
+		local_table_suffix = "_shard"
+		sharding_key       = "rand()"
+	}`, "name"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_create_distributed_table", "CREATE TABLE `customer` ON CLUSTER `main` AS `customer_shard`\n"+
+						"ENGINE = Distributed('main', currentDatabase(), 'customer_shard', rand())\n"),
+					resource.TestMatchResourceAttr("data.datatools_psql2ch.test", "clickhouse_create_table", regexp.MustCompile(
+						"ENGINE = ReplicatedMergeTree\\('/tables/\\{cluster\\}/\\{shard\\}/customer', '\\{replica\\}-\\{shard\\}'\\)\n")),
+				),
+			},
+		},
+	})
+}
+
+func TestCompositeFieldPattern(t *testing.T) {
+	testCases := map[string][]string{
+		`(1,foo)`:                   {"1", "foo"},
+		`("Main street, 5",75001)`:  {"Main street, 5", "75001"},
+		`("say ""hi""","a\\b (c)")`: {`say "hi"`, `a\b (c)`},
+		`(,"")`:                     {"", ""},
+	}
+
+	fieldPattern := regexp.MustCompile(compositeFieldPattern)
+	escapePattern := regexp.MustCompile(compositeEscapePattern)
+	for value, expected := range testCases {
+		matches := fieldPattern.FindAllStringSubmatch(","+strings.Trim(value, "()"), -1)
+		if len(matches) != len(expected) {
+			t.Errorf("%s: expected %d fields, got %d", value, len(expected), len(matches))
+			continue
+		}
+		for i, match := range matches {
+			field := match[1]
+			if strings.HasPrefix(field, `"`) {
+				field = escapePattern.ReplaceAllString(field[1:len(field)-1], "$1")
+			}
+			if field != expected[i] {
+				t.Errorf("%s: expected field %d to be %q, got %q", value, i+1, expected[i], field)
+			}
+		}
+	}
+}
+
+func TestPsql2chIdentityHash(t *testing.T) {
+	identity := psql2chIdentity{
+		TableName: "product",
+		Columns: []psqlColumnIdentity{{
+			Definition: psqlColumnDefinition{Name: "key_id", Type: "int4", IsPrimaryKey: true},
+		}},
+	}
+	_, id := identity.hash()
+	_, sameId := identity.hash()
+	if id != sameId {
+		t.Errorf("expected a deterministic identifier, got %s and %s", id, sameId)
+	}
+	identity.Columns[0].Definition.Type = "int8"
+	_, otherTypeId := identity.hash()
+	if id == otherTypeId {
+		t.Errorf("expected the identifier to change with the column type")
+	}
+	identity.Columns[0].Definition.Type = "int4"
+	identity.Columns[0].ClickhouseType = "UInt32"
+	_, overrideId := identity.hash()
+	if id == overrideId {
+		t.Errorf("expected the identifier to change with the column overrides")
+	}
+}
+
+func TestAccPsql2ChDataSourceMappingProfile(t *testing.T) {
+	mappingProfileFile := filepath.Join(t.TempDir(), "datatools-mappings.yaml")
+	err := os.WriteFile(mappingProfileFile, []byte(testAccPsql2ChDataSourceMappingProfile), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPsql2ChDataSourceConfigCaseMappingProfile(filepath.Join(t.TempDir(), "missing.yaml")),
+				ExpectError: regexp.MustCompile(`Invalid Mapping Profile File`),
+			},
+			{
+				Config: testAccPsql2ChDataSourceConfigCaseMappingProfile(mappingProfileFile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.0.type", "UInt32"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.1.type", "Nullable(String)"),
+					resource.TestCheckResourceAttr("data.datatools_psql2ch.test", "clickhouse_columns.2.type", "Nullable(Decimal(12, 2))"),
+				),
+			},
 		},
 	})
 }
@@ -310,3 +898,721 @@ data "datatools_psql2ch" "test" {
 	  ]
 }
 `
+
+const testAccPsql2ChDataSourceConfigCaseArrays = `
+data "datatools_psql2ch" "test" {
+	postgres_columns = [{
+		name                     = "key_id"
+		type                     = "int4"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "permissions"
+		type                     = "_int4"
+		is_primary_key           = false
+		is_nullable 			 = false
+	  }, {
+		name                     = "tags"
+		type                     = "varchar[]"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }, {
+		name                     = "slots"
+		type                     = "timestamptz[][]"
+		is_primary_key           = false
+		datetime_precision       = 6
+		is_nullable 			 = true
+	  }
+	  ]
+}
+`
+
+const testAccPsql2ChDataSourceConfigCaseJSON = `
+data "datatools_psql2ch" "test" {
+	json_strategy = "Map"
+	postgres_columns = [{
+		name                     = "key_id"
+		type                     = "int4"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "payload"
+		type                     = "json"
+		is_primary_key           = false
+		is_nullable 			 = true
+		json_strategy            = "String"
+	  }, {
+		name                     = "attributes"
+		type                     = "jsonb"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }, {
+		name                     = "document"
+		type                     = "jsonb"
+		is_primary_key           = false
+		is_nullable 			 = true
+		json_strategy            = "JSON"
+		json_struct              = "struct<id:int,name:string>"
+	  }
+	  ]
+}
+`
+
+const testAccPsql2ChDataSourceConfigCaseNetwork = `
+provider "datatools" {
+	inet_type = "IPv4"
+}
+
+data "datatools_psql2ch" "test" {
+	postgres_columns = [{
+		name                     = "request_id"
+		type                     = "uuid"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "client_ip"
+		type                     = "inet"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }, {
+		name                     = "server_ip"
+		type                     = "inet"
+		is_primary_key           = false
+		is_nullable 			 = false
+		inet_type                = "IPv6"
+	  }, {
+		name                     = "network"
+		type                     = "cidr"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }
+	  ]
+}
+`
+
+const testAccPsql2ChDataSourceConfigCaseEnum = `
+data "datatools_psql2ch" "test" {
+	postgres_columns = [{
+		name                     = "key_id"
+		type                     = "int4"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "status"
+		type                     = "payment_status"
+		is_primary_key           = false
+		is_nullable 			 = true
+		enum_values              = ["pending", "paid", "refunded"]
+	  }, {
+		name                     = "currency"
+		type                     = "currency_code"
+		is_primary_key           = false
+		is_nullable 			 = false
+		enum_values              = ["EUR", "USD"]
+		enum_strategy            = "LowCardinality"
+	  }
+	  ]
+}
+`
+
+func testAccPsql2ChDataSourceConfigCaseTime(options string) string {
+	return fmt.Sprintf(`
+data "datatools_psql2ch" "test" {
+	%s
+	postgres_columns = [{
+		name                     = "key_id"
+		type                     = "int4"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "opens_at"
+		type                     = "time"
+		is_primary_key           = false
+		datetime_precision       = 3
+		is_nullable 			 = true
+	  }, {
+		name                     = "closes_at"
+		type                     = "timetz"
+		is_primary_key           = false
+		datetime_precision       = 6
+		is_nullable 			 = false
+	  }, {
+		name                     = "sla"
+		type                     = "interval"
+		is_primary_key           = false
+		datetime_precision       = 6
+		is_nullable 			 = true
+	  }, {
+		name                     = "starts_at"
+		type                     = "time"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }
+	  ]
+}
+`, options)
+}
+
+const testAccPsql2ChDataSourceConfigCaseTimezone = `
+provider "datatools" {
+	timezone = "Europe/Paris"
+}
+
+data "datatools_psql2ch" "test" {
+	postgres_columns = [{
+		name                     = "key_id"
+		type                     = "int4"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "created_at"
+		type                     = "timestamp"
+		is_primary_key           = false
+		datetime_precision       = 6
+		is_nullable 			 = false
+	  }, {
+		name                     = "updated_at"
+		type                     = "timestamptz"
+		is_primary_key           = false
+		datetime_precision       = 3
+		is_nullable 			 = true
+	  }, {
+		name                     = "deleted_at"
+		type                     = "timestamptz"
+		is_primary_key           = false
+		datetime_precision       = 6
+		is_nullable 			 = true
+		timezone                 = "America/New_York"
+	  }
+	  ]
+}
+`
+
+const testAccPsql2ChDataSourceConfigCaseBinary = `
+data "datatools_psql2ch" "test" {
+	binary_handling_mode = "hex"
+	postgres_columns = [{
+		name                     = "key_id"
+		type                     = "int4"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "document_hash"
+		type                     = "bytea"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }, {
+		name                     = "flags"
+		type                     = "bit"
+		character_maximum_length = 12
+		is_primary_key           = false
+		is_nullable 			 = false
+	  }, {
+		name                     = "bitmap"
+		type                     = "bit"
+		character_maximum_length = 128
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }, {
+		name                     = "mask"
+		type                     = "varbit"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }
+	  ]
+}
+`
+
+const testAccPsql2ChDataSourceConfigCaseComposite = `
+data "datatools_psql2ch" "test" {
+	postgres_columns = [{
+		name                     = "key_id"
+		type                     = "int4"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "attributes"
+		type                     = "hstore"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }, {
+		name                     = "address"
+		type                     = "postal_address"
+		is_primary_key           = false
+		is_nullable 			 = true
+		fields                   = [{
+			name = "street"
+			type = "text"
+		}, {
+			name = "zip_code"
+			type = "int4"
+		}]
+	  }
+	  ]
+}
+`
+
+const testAccPsql2ChDataSourceConfigCaseGeometry = `
+data "datatools_psql2ch" "test" {
+	postgres_columns = [{
+		name                     = "key_id"
+		type                     = "int4"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "position"
+		type                     = "geography(Point,4326)"
+		is_primary_key           = false
+		is_nullable 			 = true
+		geometry_type            = "Point"
+	  }, {
+		name                     = "area"
+		type                     = "geometry"
+		is_primary_key           = false
+		is_nullable 			 = true
+		geometry_type            = "MultiPolygon"
+		geometry_format          = "WKT"
+	  }, {
+		name                     = "shape"
+		type                     = "geometry"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }
+	  ]
+}
+`
+
+const testAccPsql2ChDataSourceConfigCaseRange = `
+data "datatools_psql2ch" "test" {
+	postgres_columns = [{
+		name                     = "key_id"
+		type                     = "int4"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "booking"
+		type                     = "tstzrange"
+		is_primary_key           = false
+		datetime_precision       = 6
+		is_nullable 			 = true
+	  }, {
+		name                     = "validity"
+		type                     = "daterange"
+		is_primary_key           = false
+		is_nullable 			 = true
+		range_strategy           = "Split"
+	  }, {
+		name                     = "seats"
+		type                     = "int4multirange"
+		is_primary_key           = false
+		is_nullable 			 = false
+	  }
+	  ]
+}
+`
+
+const testAccPsql2ChDataSourceConfigCaseTypeSpellings = `
+data "datatools_psql2ch" "test" {
+	postgres_columns = [{
+		name                     = "key_id"
+		type                     = "integer"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "label"
+		type                     = "character varying(255)"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }, {
+		name                     = "created_at"
+		type                     = "timestamp(3) with time zone"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }, {
+		name                     = "amount"
+		type                     = "numeric(12,2)"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }, {
+		name                     = "rate"
+		type                     = "numeric(12,2)"
+		is_primary_key           = false
+		numeric_precision        = 10
+		numeric_scale            = 4
+		is_nullable 			 = true
+	  }, {
+		name                     = "related_ids"
+		type                     = "bigint[]"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }, {
+		name                     = "updated_at"
+		type                     = "timestamp with time zone"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }
+	  ]
+}
+`
+
+const testAccPsql2ChDataSourceConfigCaseDomain = `
+data "datatools_psql2ch" "test" {
+	postgres_columns = [{
+		name                     = "key_id"
+		type                     = "int4"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "email"
+		type                     = "email_address"
+		base_type                = "citext"
+		is_primary_key           = false
+		is_nullable 			 = false
+	  }, {
+		name                     = "amount"
+		type                     = "positive_amount"
+		base_type                = "numeric(12,2)"
+		domain_checks            = ["CHECK (VALUE > 0)"]
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }
+	  ]
+}
+`
+
+func testAccPsql2ChDataSourceConfigCaseUnsupportedType(options string) string {
+	return fmt.Sprintf(`
+data "datatools_psql2ch" "test" {
+	%s
+	postgres_columns = [{
+		name                     = "key_id"
+		type                     = "int4"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "search"
+		type                     = "tsvector"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }
+	  ]
+}
+`, options)
+}
+
+const testAccPsql2ChDataSourceConfigCaseUnsupportedTypeOverride = `
+data "datatools_psql2ch" "test" {
+	on_unsupported_type = "warn"
+	postgres_columns = [{
+		name                     = "key_id"
+		type                     = "int4"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "search"
+		type                     = "tsvector"
+		is_primary_key           = false
+		is_nullable 			 = true
+		clickhouse_type          = "Tuple(a Int32)"
+	  }
+	  ]
+}
+`
+
+const testAccPsql2ChDataSourceConfigCaseUnsupportedTypes = `
+data "datatools_psql2ch" "test" {
+	postgres_columns = [{
+		name                     = "key_id"
+		type                     = "int4"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "search"
+		type                     = "tsvector"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }, {
+		name                     = "position"
+		type                     = "pg_lsn"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }
+	  ]
+}
+`
+
+const testAccPsql2ChDataSourceConfigCaseOverrides = `
+data "datatools_psql2ch" "test" {
+	postgres_columns = [{
+		name                     = "key_id"
+		type                     = "int8"
+		clickhouse_type          = "UInt64"
+		kafkaengine_expression   = "toUInt64(` + "`key_id`" + `)"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "country"
+		type                     = "text"
+		clickhouse_type          = "LowCardinality(String)"
+		athena_type              = "varchar(32)"
+		is_primary_key           = false
+		is_nullable 			 = false
+	  }, {
+		name                     = "search"
+		type                     = "tsvector"
+		clickhouse_type          = "Nullable(String)"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }
+	  ]
+}
+`
+
+const testAccPsql2ChDataSourceConfigCaseInvalidOverride = `
+data "datatools_psql2ch" "test" {
+	postgres_columns = [{
+		name                     = "key_id"
+		type                     = "int8"
+		clickhouse_type          = "Uint64"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }
+	  ]
+}
+`
+
+const testAccPsql2ChDataSourceConfigCaseTypeMapping = `
+provider "datatools" {
+	type_mapping = [{
+		type                   = "int8"
+		clickhouse_type        = "UInt64"
+		kafkaengine_expression = "toUInt64({column})"
+	  }, {
+		type                   = "numeric"
+		numeric_precision_min  = 39
+		clickhouse_type        = "String"
+	  }, {
+		type                   = "tsvector"
+		clickhouse_type        = "String"
+	  }
+	  ]
+}
+
+data "datatools_psql2ch" "test" {
+	postgres_columns = [{
+		name                     = "key_id"
+		type                     = "int8"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "huge"
+		type                     = "numeric(50,0)"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }, {
+		name                     = "amount"
+		type                     = "numeric(12,2)"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }, {
+		name                     = "counter"
+		type                     = "int8"
+		clickhouse_type          = "UInt32"
+		is_primary_key           = false
+		is_nullable 			 = false
+	  }, {
+		name                     = "search"
+		type                     = "tsvector"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }
+	  ]
+}
+`
+
+const testAccPsql2ChDataSourceConfigCaseInvalidTypeMapping = `
+provider "datatools" {
+	type_mapping = [{
+		type                   = "int8("
+		clickhouse_type        = "UInt64"
+	  }
+	  ]
+}
+
+data "datatools_psql2ch" "test" {
+	postgres_columns = [{
+		name                     = "key_id"
+		type                     = "int8"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }
+	  ]
+}
+`
+
+const testAccPsql2ChDataSourceMappingProfile = `
+type_mapping:
+  - type: int8
+    clickhouse_type: UInt64
+  - type: numeric
+    numeric_precision_min: 39
+    clickhouse_type: String
+`
+
+func testAccPsql2ChDataSourceConfigCaseMappingProfile(mappingProfileFile string) string {
+	return fmt.Sprintf(`
+provider "datatools" {
+	mapping_profile_file = %q
+	type_mapping = [{
+		type                   = "int8"
+		clickhouse_type        = "UInt32"
+	  }
+	  ]
+}
+
+data "datatools_psql2ch" "test" {
+	postgres_columns = [{
+		name                     = "key_id"
+		type                     = "int8"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "huge"
+		type                     = "numeric(50,0)"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }, {
+		name                     = "amount"
+		type                     = "numeric(12,2)"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }
+	  ]
+}
+`, mappingProfileFile)
+}
+
+func testAccPsql2ChDataSourceConfigCasePrimaryKeyGuessing(options string) string {
+	return fmt.Sprintf(`
+data "datatools_psql2ch" "test" {
+	table_name = "product_history_change"
+	%s
+	postgres_columns = [{
+		name                     = "product_id"
+		type                     = "int8"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }, {
+		name                     = "product_history_change_id"
+		type                     = "int8"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }
+	  ]
+}
+`, options)
+}
+
+const testAccPsql2ChDataSourceConfigCasePrimaryKeyGuessingDeclared = `
+data "datatools_psql2ch" "test" {
+	table_name = "product_history_change"
+	postgres_columns = [{
+		name                     = "change_uuid"
+		type                     = "uuid"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "product_history_change_id"
+		type                     = "int8"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }
+	  ]
+}
+`
+
+func testAccPsql2ChDataSourceConfigCaseTableMetadata(constraintColumns string) string {
+	return fmt.Sprintf(`
+data "datatools_psql2ch" "test" {
+	schema_name = "shop"
+	table_name  = "product"
+	unique_constraints = [{
+		name    = "product_tenant_sku_key"
+		columns = [%s]
+	  }
+	  ]
+	postgres_columns = [{
+		name                     = "category_id"
+		type                     = "int8"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }, {
+		name                     = "sku"
+		type                     = "text"
+		is_primary_key           = false
+		is_nullable 			 = false
+	  }, {
+		name                     = "tenant"
+		type                     = "text"
+		is_primary_key           = false
+		is_nullable 			 = false
+	  }
+	  ]
+}
+`, constraintColumns)
+}
+
+const testAccPsql2ChDataSourceConfigCaseCreateTable = `
+data "datatools_psql2ch" "test" {
+	table_name = "order"
+	clickhouse_table = {
+		database     = "analytics"
+		partition_by = "toYYYYMM(` + "`created_at`" + `)"
+		settings = {
+			index_granularity = 8192
+			storage_policy    = "hot"
+		}
+		comment = "Orders"
+	}
+	postgres_columns = [{
+		name                     = "id"
+		type                     = "int8"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "created_at"
+		type                     = "timestamptz"
+		datetime_precision       = 6
+		is_primary_key           = false
+		is_nullable 			 = false
+	  }, {
+		name                     = "note"
+		type                     = "text"
+		is_primary_key           = false
+		is_nullable 			 = true
+		comment                  = "Customer's note"
+	  }
+	  ]
+}
+`
+
+func testAccPsql2ChDataSourceConfigCaseCDC(options string, columnName string) string {
+	return fmt.Sprintf(`
+data "datatools_psql2ch" "test" {
+	table_name = "customer"
+	%s
+	postgres_columns = [{
+		name                     = "id"
+		type                     = "int8"
+		is_primary_key           = true
+		is_nullable 			 = false
+	  }, {
+		name                     = "%s"
+		type                     = "text"
+		is_primary_key           = false
+		is_nullable 			 = true
+	  }
+	  ]
+}
+`, options, columnName)
+}
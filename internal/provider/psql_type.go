@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PsqlTypeName is a PostgreSQL type name as printed by \d or pg_dump, normalized
+// to its udt name with the modifiers extracted from the type string.
+type PsqlTypeName struct {
+	// Type is the udt name followed by one [] per array dimension, e.g. int4[].
+	Type                   string
+	Name                   string
+	ArrayDimensions        int
+	NumericPrecision       types.Int64
+	NumericScale           types.Int64
+	CharacterMaximumLength types.Int64
+	DatetimePrecision      types.Int64
+}
+
+// PostgreSqlDefaultDatetimePrecision is the precision of the time and timestamp types
+// declared without modifier, microseconds.
+const PostgreSqlDefaultDatetimePrecision = 6
+
+// postgreSqlTypeAliases maps the SQL standard and PostgreSQL alias spellings to udt names.
+var postgreSqlTypeAliases = map[string]string{
+	"smallint":                    "int2",
+	"smallserial":                 "int2",
+	"serial2":                     "int2",
+	"integer":                     "int4",
+	"int":                         "int4",
+	"serial":                      "int4",
+	"serial4":                     "int4",
+	"bigint":                      "int8",
+	"bigserial":                   "int8",
+	"serial8":                     "int8",
+	"real":                        "float4",
+	"double precision":            "float8",
+	"float":                       "float8",
+	"decimal":                     "numeric",
+	"character varying":           "varchar",
+	"char varying":                "varchar",
+	"character":                   "bpchar",
+	"char":                        "bpchar",
+	"boolean":                     "bool",
+	"timestamp without time zone": "timestamp",
+	"timestamp with time zone":    "timestamptz",
+	"time without time zone":      "time",
+	"time with time zone":         "timetz",
+	"bit varying":                 "varbit",
+}
+
+var (
+	psqlTypeArraySuffix    = regexp.MustCompile(`\s*(\[\s*\d*\s*\]|\barray(\s*\[\s*\d*\s*\])?)$`)
+	psqlTypeModifiers      = regexp.MustCompile(`\s*\(\s*(\d+)\s*(?:,\s*(-?\d+)\s*)?\)`)
+	psqlTypeOtherModifiers = regexp.MustCompile(`\s*\([^()]*\)`)
+	psqlTypeInterval       = regexp.MustCompile(`^interval(\s+(year|month|day|hour|minute|second)(\s+to\s+(month|hour|minute|second))?)?$`)
+	psqlTypeSpaces         = regexp.MustCompile(`\s+`)
+)
+
+// parsePostgreSqlType parses a PostgreSQL type name in any of its spellings, e.g. integer,
+// character varying(255), timestamp(3) with time zone, numeric(12,2), int4[] or _int4.
+// Unknown names, such as user defined types, are kept as is.
+func parsePostgreSqlType(psqlType string) PsqlTypeName {
+	typeName := PsqlTypeName{
+		NumericPrecision:       types.Int64Null(),
+		NumericScale:           types.Int64Null(),
+		CharacterMaximumLength: types.Int64Null(),
+		DatetimePrecision:      types.Int64Null(),
+	}
+
+	name := strings.TrimSpace(psqlType)
+	// Quoted identifiers are case sensitive
+	if strings.Contains(name, `"`) {
+		name = strings.ReplaceAll(name, `"`, "")
+	} else {
+		name = strings.ToLower(name)
+	}
+	name = strings.TrimPrefix(name, "pg_catalog.")
+
+	for psqlTypeArraySuffix.MatchString(name) {
+		name = psqlTypeArraySuffix.ReplaceAllString(name, "")
+		typeName.ArrayDimensions++
+	}
+
+	var modifiers []int64
+	if matches := psqlTypeModifiers.FindStringSubmatch(name); matches != nil {
+		for _, match := range matches[1:] {
+			if match == "" {
+				continue
+			}
+			modifier, _ := strconv.ParseInt(match, 10, 64)
+			modifiers = append(modifiers, modifier)
+		}
+		name = psqlTypeModifiers.ReplaceAllString(name, " ")
+	}
+	// Non numeric modifiers, e.g. geometry(Point,4326), aren't parsed
+	name = psqlTypeOtherModifiers.ReplaceAllString(name, " ")
+	name = strings.TrimSpace(psqlTypeSpaces.ReplaceAllString(name, " "))
+
+	// udt names of arrays, e.g. _int4
+	if typeName.ArrayDimensions == 0 && strings.HasPrefix(name, "_") {
+		name = strings.TrimPrefix(name, "_")
+		typeName.ArrayDimensions = 1
+	}
+
+	// float(p) is a real up to 24 bits of precision
+	if name == "float" && len(modifiers) > 0 && modifiers[0] <= 24 {
+		name = "real"
+	}
+	if alias, ok := postgreSqlTypeAliases[name]; ok {
+		name = alias
+	}
+	if psqlTypeInterval.MatchString(name) {
+		name = "interval"
+	}
+
+	switch name {
+	case "numeric":
+		if len(modifiers) > 0 {
+			typeName.NumericPrecision = types.Int64Value(modifiers[0])
+			typeName.NumericScale = types.Int64Value(0)
+		}
+		if len(modifiers) > 1 {
+			typeName.NumericScale = types.Int64Value(modifiers[1])
+		}
+	case "varchar", "bpchar", "bit", "varbit":
+		if len(modifiers) > 0 {
+			typeName.CharacterMaximumLength = types.Int64Value(modifiers[0])
+		}
+	case "timestamp", "timestamptz", "time", "timetz":
+		typeName.DatetimePrecision = types.Int64Value(PostgreSqlDefaultDatetimePrecision)
+		if len(modifiers) > 0 {
+			typeName.DatetimePrecision = types.Int64Value(modifiers[0])
+		}
+	case "interval":
+		if len(modifiers) > 0 {
+			typeName.DatetimePrecision = types.Int64Value(modifiers[0])
+		}
+	case "tsrange", "tstzrange", "tsmultirange", "tstzmultirange":
+		typeName.DatetimePrecision = types.Int64Value(PostgreSqlDefaultDatetimePrecision)
+	}
+
+	typeName.Name = name
+	typeName.Type = name + strings.Repeat("[]", typeName.ArrayDimensions)
+	return typeName
+}
@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParsePostgreSqlType(t *testing.T) {
+	testCases := map[string]PsqlTypeName{
+		"int4": {
+			Type: "int4",
+		},
+		"integer": {
+			Type: "int4",
+		},
+		"BIGSERIAL": {
+			Type: "int8",
+		},
+		"double precision": {
+			Type: "float8",
+		},
+		"float(10)": {
+			Type: "float4",
+		},
+		"float(53)": {
+			Type: "float8",
+		},
+		"character varying(255)": {
+			Type:                   "varchar",
+			CharacterMaximumLength: types.Int64Value(255),
+		},
+		"char(3)": {
+			Type:                   "bpchar",
+			CharacterMaximumLength: types.Int64Value(3),
+		},
+		"numeric(12,2)": {
+			Type:             "numeric",
+			NumericPrecision: types.Int64Value(12),
+			NumericScale:     types.Int64Value(2),
+		},
+		"decimal(10)": {
+			Type:             "numeric",
+			NumericPrecision: types.Int64Value(10),
+			NumericScale:     types.Int64Value(0),
+		},
+		"timestamp(3) with time zone": {
+			Type:              "timestamptz",
+			DatetimePrecision: types.Int64Value(3),
+		},
+		"timestamp without time zone": {
+			Type:              "timestamp",
+			DatetimePrecision: types.Int64Value(6),
+		},
+		"time": {
+			Type:              "time",
+			DatetimePrecision: types.Int64Value(6),
+		},
+		"tstzrange": {
+			Type:              "tstzrange",
+			DatetimePrecision: types.Int64Value(6),
+		},
+		"time(0) with time zone": {
+			Type:              "timetz",
+			DatetimePrecision: types.Int64Value(0),
+		},
+		"interval day to second(3)": {
+			Type:              "interval",
+			DatetimePrecision: types.Int64Value(3),
+		},
+		"bit varying(8)": {
+			Type:                   "varbit",
+			CharacterMaximumLength: types.Int64Value(8),
+		},
+		"integer[]": {
+			Type: "int4[]",
+		},
+		"text[][]": {
+			Type: "text[][]",
+		},
+		"character varying(32)[3]": {
+			Type:                   "varchar[]",
+			CharacterMaximumLength: types.Int64Value(32),
+		},
+		"integer ARRAY": {
+			Type: "int4[]",
+		},
+		"_int4": {
+			Type: "int4[]",
+		},
+		"pg_catalog.int8": {
+			Type: "int8",
+		},
+		"geometry(Point,4326)": {
+			Type: "geometry",
+		},
+		`"MyEnum"`: {
+			Type: "MyEnum",
+		},
+	}
+
+	for psqlType, expected := range testCases {
+		actual := parsePostgreSqlType(psqlType)
+		if actual.Type != expected.Type {
+			t.Errorf("%s: expected type %s, got %s", psqlType, expected.Type, actual.Type)
+		}
+		if !actual.NumericPrecision.Equal(expected.NumericPrecision) {
+			t.Errorf("%s: expected numeric precision %s, got %s", psqlType, expected.NumericPrecision, actual.NumericPrecision)
+		}
+		if !actual.NumericScale.Equal(expected.NumericScale) {
+			t.Errorf("%s: expected numeric scale %s, got %s", psqlType, expected.NumericScale, actual.NumericScale)
+		}
+		if !actual.CharacterMaximumLength.Equal(expected.CharacterMaximumLength) {
+			t.Errorf("%s: expected character maximum length %s, got %s", psqlType, expected.CharacterMaximumLength, actual.CharacterMaximumLength)
+		}
+		if !actual.DatetimePrecision.Equal(expected.DatetimePrecision) {
+			t.Errorf("%s: expected datetime precision %s, got %s", psqlType, expected.DatetimePrecision, actual.DatetimePrecision)
+		}
+	}
+}
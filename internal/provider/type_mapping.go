@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TypeMappingRuleModel describes a type_mapping rule of the provider.
+type TypeMappingRuleModel struct {
+	Type                      types.String `tfsdk:"type"`
+	NumericPrecisionMin       types.Int64  `tfsdk:"numeric_precision_min"`
+	NumericPrecisionMax       types.Int64  `tfsdk:"numeric_precision_max"`
+	NumericScaleMin           types.Int64  `tfsdk:"numeric_scale_min"`
+	NumericScaleMax           types.Int64  `tfsdk:"numeric_scale_max"`
+	CharacterMaximumLengthMin types.Int64  `tfsdk:"character_maximum_length_min"`
+	CharacterMaximumLengthMax types.Int64  `tfsdk:"character_maximum_length_max"`
+	ClickhouseType            types.String `tfsdk:"clickhouse_type"`
+	KafkaEngineType           types.String `tfsdk:"kafkaengine_type"`
+	KafkaEngineExpression     types.String `tfsdk:"kafkaengine_expression"`
+	AthenaType                types.String `tfsdk:"athena_type"`
+}
+
+// TypeMappingRule is a compiled type mapping rule, consulted before the built-in mapping.
+type TypeMappingRule struct {
+	// Type matches the whole normalized PostgreSQL type, e.g. numeric or int4[]
+	Type                      *regexp.Regexp
+	NumericPrecisionMin       *int64
+	NumericPrecisionMax       *int64
+	NumericScaleMin           *int64
+	NumericScaleMax           *int64
+	CharacterMaximumLengthMin *int64
+	CharacterMaximumLengthMax *int64
+	ClickhouseType            string
+	KafkaEngineType           string
+	KafkaEngineExpression     string
+	AthenaType                string
+}
+
+// TypeMappingColumnPlaceholder is replaced by the quoted column name in the rule kafka engine expression.
+const TypeMappingColumnPlaceholder = "{column}"
+
+// compile returns the rule with its type pattern compiled.
+func (m TypeMappingRuleModel) compile() (error, TypeMappingRule) {
+	var rule TypeMappingRule
+	pattern, err := regexp.Compile("^(?:" + m.Type.ValueString() + ")$")
+	if err != nil {
+		return err, rule
+	}
+	rule = TypeMappingRule{
+		Type:                      pattern,
+		NumericPrecisionMin:       m.NumericPrecisionMin.ValueInt64Pointer(),
+		NumericPrecisionMax:       m.NumericPrecisionMax.ValueInt64Pointer(),
+		NumericScaleMin:           m.NumericScaleMin.ValueInt64Pointer(),
+		NumericScaleMax:           m.NumericScaleMax.ValueInt64Pointer(),
+		CharacterMaximumLengthMin: m.CharacterMaximumLengthMin.ValueInt64Pointer(),
+		CharacterMaximumLengthMax: m.CharacterMaximumLengthMax.ValueInt64Pointer(),
+		ClickhouseType:            m.ClickhouseType.ValueString(),
+		KafkaEngineType:           m.KafkaEngineType.ValueString(),
+		KafkaEngineExpression:     m.KafkaEngineExpression.ValueString(),
+		AthenaType:                m.AthenaType.ValueString(),
+	}
+	return nil, rule
+}
+
+func (r TypeMappingRule) matches(column psqlColumnDefinition) bool {
+	return r.Type.MatchString(column.Type) &&
+		inRange(column.NumericPrecision, column.HasNumericPrecision, r.NumericPrecisionMin, r.NumericPrecisionMax) &&
+		inRange(column.NumericScale, column.HasNumericScale, r.NumericScaleMin, r.NumericScaleMax) &&
+		inRange(column.CharacterMaximumLength, column.HasCharacterMaximumLength, r.CharacterMaximumLengthMin, r.CharacterMaximumLengthMax)
+}
+
+// apply fills the column overrides left unset with the rule targets, the rule types
+// being made Nullable for nullable columns.
+func (r TypeMappingRule) apply(column PsqlColumn, definition psqlColumnDefinition) PsqlColumn {
+	if column.ClickhouseType.IsNull() && r.ClickhouseType != "" {
+		column.ClickhouseType = types.StringValue(nullableClickhouseType(r.ClickhouseType, definition.isNullable()))
+	}
+	if column.KafkaEngineType.IsNull() && r.KafkaEngineType != "" {
+		column.KafkaEngineType = types.StringValue(nullableClickhouseType(r.KafkaEngineType, definition.isNullable()))
+	}
+	// Split ranges have one expression per bound
+	if column.KafkaEngineExpression.IsNull() && r.KafkaEngineExpression != "" && !definition.isSplitRange() {
+		expression := strings.ReplaceAll(r.KafkaEngineExpression, TypeMappingColumnPlaceholder, "`"+definition.Name+"`")
+		column.KafkaEngineExpression = types.StringValue(expression)
+	}
+	if column.AthenaType.IsNull() && r.AthenaType != "" {
+		column.AthenaType = types.StringValue(r.AthenaType)
+	}
+	return column
+}
+
+// findTypeMappingRule returns the first rule matching the column.
+func findTypeMappingRule(rules []TypeMappingRule, column psqlColumnDefinition) (TypeMappingRule, bool) {
+	for _, rule := range rules {
+		if rule.matches(column) {
+			return rule, true
+		}
+	}
+	return TypeMappingRule{}, false
+}
+
+// inRange tells if the value is within the bounds, an absent value being out of any bound.
+func inRange(value int64, present bool, min *int64, max *int64) bool {
+	if min == nil && max == nil {
+		return true
+	}
+	return present && (min == nil || value >= *min) && (max == nil || value <= *max)
+}
+
+func nullableClickhouseType(clickhouseType string, nullable bool) string {
+	if nullable && canBeNullable(clickhouseType) && !strings.HasPrefix(clickhouseType, "Nullable(") {
+		return "Nullable(" + clickhouseType + ")"
+	}
+	return clickhouseType
+}
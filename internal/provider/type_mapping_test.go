@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTypeMappingRuleMatches(t *testing.T) {
+	err, rule := TypeMappingRuleModel{
+		Type:                types.StringValue("numeric"),
+		NumericPrecisionMax: types.Int64Value(18),
+		ClickhouseType:      types.StringValue("Int64"),
+	}.compile()
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	testCases := map[string]struct {
+		column   psqlColumnDefinition
+		expected bool
+	}{
+		"numeric(12)":           {psqlColumnDefinition{Type: "numeric", NumericPrecision: 12, HasNumericPrecision: true}, true},
+		"numeric(38)":           {psqlColumnDefinition{Type: "numeric", NumericPrecision: 38, HasNumericPrecision: true}, false},
+		"unconstrained numeric": {psqlColumnDefinition{Type: "numeric"}, false},
+		"int8":                  {psqlColumnDefinition{Type: "int8"}, false},
+	}
+	for name, testCase := range testCases {
+		if got := rule.matches(testCase.column); got != testCase.expected {
+			t.Errorf("%s: expected %t, got %t", name, testCase.expected, got)
+		}
+	}
+}